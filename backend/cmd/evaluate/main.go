@@ -0,0 +1,174 @@
+// Command evaluate compares recommendation strategies offline. It loads a
+// watchlist export from GET /admin/watchlists/export, holds out the titles
+// each user added last, and reports how well every strategy predicted them.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+func main() {
+	input := flag.String("input", "", "watchlist export to evaluate on (required)")
+	k := flag.Int("k", 10, "recommendations scored per user")
+	holdout := flag.Float64("holdout", 0.2, "newest share of each user's items held out")
+	cutoff := flag.String("cutoff", "", "hold out items added after this date (YYYY-MM-DD) instead")
+	minItems := flag.Int("min-items", 5, "users with fewer items are only used for training")
+	strategies := flag.String("strategies", "", "comma-separated strategies to run (default: all of "+strings.Join(services.RecommenderStrategyNames(), ", ")+")")
+	weights := flag.String("weights", "", `also run a "custom" strategy with these weights, e.g. "genre=0.4,keyword=0.1"`)
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	if *input == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	options := services.EvaluationOptions{
+		K:            *k,
+		HoldoutRatio: *holdout,
+		MinItems:     *minItems,
+	}
+	if *cutoff != "" {
+		date, err := time.Parse("2006-01-02", *cutoff)
+		if err != nil {
+			log.Fatalf("Invalid cutoff: %v", err)
+		}
+		options.Cutoff = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	watchlists, err := loadExport(*input)
+	if err != nil {
+		log.Fatalf("Failed to load export: %v", err)
+	}
+
+	// Pick the strategies to compare
+	names := services.RecommenderStrategyNames()
+	if *strategies != "" {
+		names = strings.Split(*strategies, ",")
+	}
+	var selected []services.RecommenderStrategy
+	for _, name := range names {
+		strategy, err := services.NewRecommenderStrategy(strings.TrimSpace(name))
+		if err != nil {
+			log.Fatal(err)
+		}
+		selected = append(selected, strategy)
+	}
+	if *weights != "" {
+		custom, err := parseWeights(*weights)
+		if err != nil {
+			log.Fatalf("Invalid weights: %v", err)
+		}
+		selected = append(selected, services.NewWeightedRecommender("custom", custom))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	evaluations, err := services.EvaluateRecommenders(ctx, watchlists, selected, options)
+	if err != nil {
+		log.Fatalf("Evaluation failed: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(evaluations)
+		return
+	}
+	printReport(evaluations)
+}
+
+// loadExport reads a watchlist export, either as returned by the API or just
+// its data
+func loadExport(path string) (map[string][]models.WatchlistItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data *models.WatchlistExport `json:"data"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Data != nil {
+		return response.Data.Watchlists, nil
+	}
+
+	var export models.WatchlistExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+	return export.Watchlists, nil
+}
+
+// parseWeights overrides the default recommendation weights with
+// comma-separated name=value pairs
+func parseWeights(spec string) (services.RecommendationWeights, error) {
+	weights := services.DefaultRecommendationWeights()
+	fields := map[string]*float64{
+		"genre":         &weights.Genre,
+		"keyword":       &weights.Keyword,
+		"people":        &weights.People,
+		"language":      &weights.Language,
+		"decade":        &weights.Decade,
+		"runtime":       &weights.Runtime,
+		"rating":        &weights.Rating,
+		"media_type":    &weights.MediaType,
+		"liked_title":   &weights.LikedTitle,
+		"popularity":    &weights.Popularity,
+		"collaborative": &weights.Collaborative,
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		field, exists := fields[name]
+		if !found || !exists {
+			return weights, fmt.Errorf("expected name=value with a known weight name, got %q", pair)
+		}
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return weights, fmt.Errorf("invalid %s weight: %w", name, err)
+		}
+		*field = weight
+	}
+
+	return weights, nil
+}
+
+// printReport prints one row per strategy
+func printReport(evaluations []models.RecommenderEvaluation) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(evaluations) > 0 {
+		k := evaluations[0].K
+		fmt.Fprintf(writer, "STRATEGY\tUSERS\tP@%d\tR@%d\tNDCG@%d\tCOVERAGE\tNOVELTY\tTIME\n", k, k, k)
+	}
+	for _, evaluation := range evaluations {
+		fmt.Fprintf(writer, "%s\t%d\t%.4f\t%.4f\t%.4f\t%.4f\t%.2f\t%s\n",
+			evaluation.Strategy,
+			evaluation.Users,
+			evaluation.Precision,
+			evaluation.Recall,
+			evaluation.NDCG,
+			evaluation.Coverage,
+			evaluation.Novelty,
+			evaluation.Duration,
+		)
+	}
+	writer.Flush()
+}
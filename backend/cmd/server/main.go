@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,6 +16,7 @@ import (
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/routes"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
 	"github.com/joho/godotenv"
 )
 
@@ -30,18 +32,119 @@ func main() {
 	// Initialize logger
 	logger := middleware.NewLogger()
 
+	// Connect to the state every replica shares
+	var redisClient *utils.RedisClient
+	var stateStore services.StateStore
+	var jobLocker services.JobLocker
+	if config.AppConfig.Shared.Store == "redis" {
+		redisClient = utils.NewRedisClient(net.JoinHostPort(config.AppConfig.Redis.Host, config.AppConfig.Redis.Port), config.AppConfig.Redis.Password)
+		if err := redisClient.Ping(context.Background()); err != nil {
+			log.Fatalf("Failed to connect to shared store: %v", err)
+		}
+		stateStore = services.NewRedisStateStore(redisClient, config.AppConfig.Shared.KeyPrefix)
+		jobLocker = services.NewRedisJobLocker(redisClient, config.AppConfig.Shared.KeyPrefix+"lock:")
+	} else {
+		logger.InfoLogger.Println("Keeping shared state in local files; set SHARED_STORE=redis to run more than one instance")
+		stateStore = services.NewFileStateStore(config.AppConfig.Cache.Dir)
+		jobLocker = services.NewFileJobLocker(config.AppConfig.Scheduler.LockDir)
+	}
+
 	// Initialize services
 	tmdbService := services.NewTMDBService()
 	omdbService := services.NewOMDBService()
-	watchlistService := services.NewWatchlistService(tmdbService)
+	settingsService := services.NewSettingsService()
+	experimentService := services.NewExperimentService()
+	if err := experimentService.Load(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load recommendation experiment: %v", err)
+	}
+	watchlistService := services.NewWatchlistService(tmdbService, settingsService, experimentService)
+	if err := watchlistService.LoadRecommendationFeedback(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load recommendation feedback: %v", err)
+	}
+	notificationService := services.NewNotificationService()
+	availabilityService := services.NewAvailabilityService(tmdbService, watchlistService, settingsService, notificationService)
+	if err := availabilityService.Load(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load availability baseline: %v", err)
+	}
+	calendarService := services.NewCalendarService(tmdbService, watchlistService, settingsService, stateStore, jobLocker)
+	pickService := services.NewPickService(tmdbService, watchlistService, settingsService)
+	partyService := services.NewPartyService(watchlistService, pickService, stateStore, jobLocker)
+	suggestService := services.NewSuggestService(tmdbService, watchlistService)
+	searchIndexService := services.NewSearchIndexService(tmdbService)
+	if err := searchIndexService.Load(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load search index: %v", err)
+	}
+	trendingSnapshotService := services.NewTrendingSnapshotService(tmdbService, stateStore)
+	if err := trendingSnapshotService.Load(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load trending snapshots: %v", err)
+	}
+	followService := services.NewFollowService(tmdbService, settingsService, notificationService)
+	if err := followService.Load(); err != nil {
+		logger.ErrorLogger.Printf("Failed to load followed people: %v", err)
+	}
+	schedulerService := services.NewSchedulerService(
+		jobLocker,
+		config.AppConfig.Scheduler.LockTTL,
+		config.AppConfig.Scheduler.Jitter,
+		func(job string, err error) {
+			logger.ErrorLogger.Printf("Background job %s failed: %v", job, err)
+		},
+	)
 
 	// Initialize controllers
-	movieController := controllers.NewMovieController(tmdbService, omdbService, logger, watchlistService)
+	movieController := controllers.NewMovieController(tmdbService, omdbService, logger, watchlistService, searchIndexService)
 	watchlistController := controllers.NewWatchlistController(watchlistService, logger)
-	trendingController := controllers.NewTrendingController(tmdbService, logger)
+	trendingController := controllers.NewTrendingController(tmdbService, trendingSnapshotService, logger)
+	suggestController := controllers.NewSuggestController(suggestService, logger)
+	peopleController := controllers.NewPeopleController(tmdbService, followService, logger)
+	tvController := controllers.NewTVController(tmdbService, watchlistService, logger)
+	providerController := controllers.NewProviderController(tmdbService, settingsService, logger)
+	discoverController := controllers.NewDiscoverController(tmdbService, settingsService, logger)
+	settingsController := controllers.NewSettingsController(settingsService, logger)
+	notificationController := controllers.NewNotificationController(notificationService, logger)
+	adminController := controllers.NewAdminController(schedulerService, watchlistService, experimentService, logger)
+	listController := controllers.NewListController(tmdbService, settingsService, logger)
+	calendarController := controllers.NewCalendarController(calendarService, logger)
+	pickController := controllers.NewPickController(pickService, logger)
+	partyController := controllers.NewPartyController(partyService, logger)
 
 	// Setup routes
-	router := routes.SetupRoutes(movieController, watchlistController, trendingController, logger)
+	router := routes.SetupRoutes(movieController, watchlistController, trendingController, suggestController, peopleController, tvController, providerController, discoverController, settingsController, notificationController, adminController, listController, calendarController, pickController, partyController, logger)
+
+	// Register background jobs. Exclusive jobs run on one replica at a time,
+	// so only jobs whose state lives in the shared store may be exclusive;
+	// jobs over a replica's own watchlists and notifications run everywhere.
+	jobs := []struct {
+		name     string
+		schedule string
+		task     services.JobFunc
+		options  services.JobOptions
+	}{
+		{"cache-warm", config.AppConfig.Scheduler.CacheWarmSchedule, tmdbService.WarmCache, services.JobOptions{RunOnStart: true}},
+		{"cache-cleanup", config.AppConfig.Scheduler.CacheCleanupSchedule, func(ctx context.Context) error {
+			tmdbService.CleanupCache(ctx)
+			omdbService.CleanupCache(ctx)
+			return watchlistService.CleanupCache(ctx)
+		}, services.JobOptions{}},
+		{"trending-snapshot", config.AppConfig.Scheduler.TrendingSnapshotSchedule, trendingSnapshotService.Capture, services.JobOptions{Exclusive: true}},
+		{"trending-snapshot-refresh", every(config.AppConfig.Cache.PersistInterval), trendingSnapshotService.Refresh, services.JobOptions{}},
+		{"suggest-refresh", every(config.AppConfig.Cache.SuggestRefreshInterval), suggestService.Refresh, services.JobOptions{RunOnStart: true}},
+		{"search-index-persist", every(config.AppConfig.Cache.PersistInterval), searchIndexService.Save, services.JobOptions{}},
+		{"experiment-persist", every(config.AppConfig.Cache.PersistInterval), experimentService.Save, services.JobOptions{}},
+		{"watchlist-refresh", every(config.AppConfig.Watchlist.RefreshInterval), watchlistService.RefreshSnapshots, services.JobOptions{RunOnStart: true}},
+		{"recommendation-model", config.AppConfig.Scheduler.RecommendationSchedule, watchlistService.RebuildSimilarityModel, services.JobOptions{RunOnStart: true}},
+		{"follow-check", config.AppConfig.Scheduler.FollowCheckSchedule, followService.CheckFollowedPeople, services.JobOptions{}},
+		{"party-cleanup", every(time.Hour), partyService.CleanupExpired, services.JobOptions{Exclusive: true}},
+		{"availability-check", every(config.AppConfig.Watchlist.AvailabilityInterval), availabilityService.CheckAvailability, services.JobOptions{RunOnStart: true}},
+	}
+	for _, job := range jobs {
+		if err := schedulerService.Register(job.name, job.schedule, job.task, job.options); err != nil {
+			log.Fatalf("Failed to register background job: %v", err)
+		}
+	}
+
+	// Start background jobs
+	schedulerService.Start(context.Background())
 
 	// Create HTTP server
 	server := &http.Server{
@@ -52,6 +155,9 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	// End watch party event streams on shutdown so they don't hold it up
+	server.RegisterOnShutdown(partyService.Close)
+
 	// Start server in a goroutine
 	go func() {
 		logger.InfoLogger.Printf("Starting server on port %s", config.AppConfig.Server.Port)
@@ -67,6 +173,9 @@ func main() {
 
 	logger.InfoLogger.Println("Shutting down server...")
 
+	// Stop background jobs and let running ones finish
+	schedulerService.Stop()
+
 	// Create a deadline for server shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -80,6 +189,15 @@ func main() {
 	tmdbService.Close()
 	omdbService.Close()
 	watchlistService.Close()
+	if err := searchIndexService.Close(); err != nil {
+		logger.ErrorLogger.Printf("Failed to persist search index: %v", err)
+	}
+	if err := experimentService.Close(); err != nil {
+		logger.ErrorLogger.Printf("Failed to persist experiment events: %v", err)
+	}
+	if redisClient != nil {
+		redisClient.Close()
+	}
 
 	logger.InfoLogger.Println("Server exited")
 }
@@ -91,11 +209,16 @@ func init() {
 	log.Println("Movie Shows Discovery Backend starting...")
 }
 
+// every converts an interval to a schedule spec
+func every(interval time.Duration) string {
+	return fmt.Sprintf("@every %s", interval)
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-User-ID")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-User-ID, X-Admin-Token")
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
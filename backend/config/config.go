@@ -10,18 +10,23 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	TMDB     TMDBConfig
-	OMDB     OMDBConfig
-	Cache    CacheConfig
-	Logging  LoggingConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	Shared     SharedConfig
+	TMDB       TMDBConfig
+	OMDB       OMDBConfig
+	Cache      CacheConfig
+	Watchlist  WatchlistConfig
+	Scheduler  SchedulerConfig
+	Experiment ExperimentConfig
+	Logging    LoggingConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port       string
+	Host       string
+	AdminToken string
 }
 
 type DatabaseConfig struct {
@@ -38,10 +43,16 @@ type RedisConfig struct {
 	Password string
 }
 
+type SharedConfig struct {
+	Store     string // "file" for a single instance, or "redis" to share state between replicas
+	KeyPrefix string
+}
+
 type TMDBConfig struct {
 	APIKey    string
 	BaseURL   string
 	RateLimit int
+	Region    string
 }
 
 type OMDBConfig struct {
@@ -51,9 +62,42 @@ type OMDBConfig struct {
 }
 
 type CacheConfig struct {
-	TTL         time.Duration
-	SearchTTL   time.Duration
-	TrendingTTL time.Duration
+	TTL                    time.Duration
+	SearchTTL              time.Duration
+	SearchIndexMaxDocs     int
+	TrendingTTL            time.Duration
+	SuggestRefreshInterval time.Duration
+	PopularTTL             time.Duration
+	TopRatedTTL            time.Duration
+	UpcomingTTL            time.Duration
+	NowPlayingTTL          time.Duration
+	AiringTTL              time.Duration
+	Dir                    string
+	PersistInterval        time.Duration
+}
+
+type WatchlistConfig struct {
+	HydrateWorkers       int
+	SnapshotTTL          time.Duration
+	RefreshInterval      time.Duration
+	AvailabilityInterval time.Duration
+}
+
+type SchedulerConfig struct {
+	Jitter                   time.Duration
+	LockDir                  string
+	LockTTL                  time.Duration
+	CacheWarmSchedule        string
+	CacheCleanupSchedule     string
+	TrendingSnapshotSchedule string
+	FollowCheckSchedule      string
+	RecommendationSchedule   string
+}
+
+type ExperimentConfig struct {
+	Name              string
+	Variants          string
+	AttributionWindow time.Duration
 }
 
 type LoggingConfig struct {
@@ -71,8 +115,9 @@ func LoadConfig() error {
 
 	AppConfig = &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Host: getEnv("HOST", "localhost"),
+			Port:       getEnv("PORT", "8080"),
+			Host:       getEnv("HOST", "localhost"),
+			AdminToken: getEnv("ADMIN_TOKEN", ""),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -86,10 +131,15 @@ func LoadConfig() error {
 			Port:     getEnv("REDIS_PORT", "6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
 		},
+		Shared: SharedConfig{
+			Store:     getEnv("SHARED_STORE", "file"),
+			KeyPrefix: getEnv("SHARED_KEY_PREFIX", "movie_discovery:"),
+		},
 		TMDB: TMDBConfig{
 			APIKey:    getEnv("TMDB_API_KEY", ""),
 			BaseURL:   getEnv("TMDB_BASE_URL", "https://api.themoviedb.org/3"),
 			RateLimit: getEnvAsInt("TMDB_RATE_LIMIT", 40),
+			Region:    getEnv("TMDB_REGION", "US"),
 		},
 		OMDB: OMDBConfig{
 			APIKey:    getEnv("OMDB_API_KEY", ""),
@@ -97,9 +147,39 @@ func LoadConfig() error {
 			RateLimit: getEnvAsInt("OMDB_RATE_LIMIT", 1000),
 		},
 		Cache: CacheConfig{
-			TTL:         getEnvAsDuration("CACHE_TTL", 3600),
-			SearchTTL:   getEnvAsDuration("SEARCH_CACHE_TTL", 1800),
-			TrendingTTL: getEnvAsDuration("TRENDING_CACHE_TTL", 3600),
+			TTL:                    getEnvAsDuration("CACHE_TTL", 3600),
+			SearchTTL:              getEnvAsDuration("SEARCH_CACHE_TTL", 1800),
+			SearchIndexMaxDocs:     getEnvAsInt("SEARCH_INDEX_MAX_DOCUMENTS", 50000),
+			TrendingTTL:            getEnvAsDuration("TRENDING_CACHE_TTL", 3600),
+			SuggestRefreshInterval: getEnvAsDuration("SUGGEST_REFRESH_INTERVAL", 3600),
+			PopularTTL:             getEnvAsDuration("POPULAR_CACHE_TTL", 3600),
+			TopRatedTTL:            getEnvAsDuration("TOP_RATED_CACHE_TTL", 86400),
+			UpcomingTTL:            getEnvAsDuration("UPCOMING_CACHE_TTL", 21600),
+			NowPlayingTTL:          getEnvAsDuration("NOW_PLAYING_CACHE_TTL", 10800),
+			AiringTTL:              getEnvAsDuration("AIRING_CACHE_TTL", 1800),
+			Dir:                    getEnv("CACHE_DIR", "data/cache"),
+			PersistInterval:        getEnvAsDuration("CACHE_PERSIST_INTERVAL", 300),
+		},
+		Watchlist: WatchlistConfig{
+			HydrateWorkers:       getEnvAsInt("WATCHLIST_HYDRATE_WORKERS", 8),
+			SnapshotTTL:          getEnvAsDuration("WATCHLIST_SNAPSHOT_TTL", 86400),
+			RefreshInterval:      getEnvAsDuration("WATCHLIST_REFRESH_INTERVAL", 3600),
+			AvailabilityInterval: getEnvAsDuration("AVAILABILITY_CHECK_INTERVAL", 21600),
+		},
+		Scheduler: SchedulerConfig{
+			Jitter:                   getEnvAsDuration("SCHEDULER_JITTER", 30),
+			LockDir:                  getEnv("SCHEDULER_LOCK_DIR", "data/locks"),
+			LockTTL:                  getEnvAsDuration("SCHEDULER_LOCK_TTL", 1800),
+			CacheWarmSchedule:        getEnv("CACHE_WARM_SCHEDULE", "*/15 * * * *"),
+			CacheCleanupSchedule:     getEnv("CACHE_CLEANUP_SCHEDULE", "*/10 * * * *"),
+			TrendingSnapshotSchedule: getEnv("TRENDING_SNAPSHOT_SCHEDULE", "0 * * * *"),
+			FollowCheckSchedule:      getEnv("FOLLOW_CHECK_SCHEDULE", "30 */6 * * *"),
+			RecommendationSchedule:   getEnv("RECOMMENDATION_MODEL_SCHEDULE", "45 */3 * * *"),
+		},
+		Experiment: ExperimentConfig{
+			Name:              getEnv("RECOMMENDATION_EXPERIMENT", ""),
+			Variants:          getEnv("RECOMMENDATION_VARIANTS", "control=hybrid,treatment=content"),
+			AttributionWindow: getEnvAsDuration("EXPERIMENT_ATTRIBUTION_WINDOW", 604800),
 		},
 		Logging: LoggingConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
@@ -113,6 +193,9 @@ func LoadConfig() error {
 	if AppConfig.OMDB.APIKey == "" {
 		return fmt.Errorf("OMDB_API_KEY is required")
 	}
+	if AppConfig.Shared.Store != "file" && AppConfig.Shared.Store != "redis" {
+		return fmt.Errorf("SHARED_STORE must be file or redis")
+	}
 
 	return nil
 }
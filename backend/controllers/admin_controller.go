@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// AdminController handles operator requests such as inspecting and running
+// background jobs
+type AdminController struct {
+	schedulerService  *services.SchedulerService
+	watchlistService  *services.WatchlistService
+	experimentService *services.ExperimentService
+	logger            *middleware.Logger
+}
+
+// NewAdminController creates a new admin controller
+func NewAdminController(schedulerService *services.SchedulerService, watchlistService *services.WatchlistService, experimentService *services.ExperimentService, logger *middleware.Logger) *AdminController {
+	return &AdminController{
+		schedulerService:  schedulerService,
+		watchlistService:  watchlistService,
+		experimentService: experimentService,
+		logger:            logger,
+	}
+}
+
+// GetJobs handles requests for the registered background jobs
+func (c *AdminController) GetJobs(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(c.schedulerService.Jobs(), "Jobs retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetJobHistory handles requests for a job's recent runs
+func (c *AdminController) GetJobHistory(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	// Get job name from URL
+	name := mux.Vars(r)["name"]
+
+	// Get history
+	history, err := c.schedulerService.History(name)
+	if err != nil {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(history, "Job history retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RunJob handles requests to run a job now
+func (c *AdminController) RunJob(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	// Get job name from URL
+	name := mux.Vars(r)["name"]
+
+	// Trigger job
+	if err := c.schedulerService.Trigger(name); err != nil {
+		switch {
+		case errors.Is(err, services.ErrJobNotFound):
+			http.Error(w, "Job not found", http.StatusNotFound)
+		case errors.Is(err, services.ErrJobRunning):
+			http.Error(w, "Job is already running", http.StatusConflict)
+		default:
+			c.logger.LogError(err, "RunJob", r)
+			http.Error(w, "Failed to run job", http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(map[string]interface{}{
+		"job": name,
+	}, "Job started")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
+// ExportWatchlists handles requests for every user's watchlist items, the
+// input of the offline recommendation evaluation
+func (c *AdminController) ExportWatchlists(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	// Create response
+	export := models.WatchlistExport{
+		ExportedAt: time.Now(),
+		Watchlists: c.watchlistService.GetAllWatchlistItems(),
+	}
+	response := models.NewSuccessResponse(export, "Watchlists exported successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetExperimentReport handles requests for how each arm of the
+// recommendation experiments converted
+func (c *AdminController) GetExperimentReport(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(c.experimentService.Report(), "Experiment report retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// authorize checks the X-Admin-Token header. Admin endpoints are disabled
+// when no ADMIN_TOKEN is configured.
+func (c *AdminController) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token := config.AppConfig.Server.AdminToken
+	if token == "" {
+		http.Error(w, "Admin endpoints are disabled", http.StatusNotFound)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+		http.Error(w, "Invalid admin token", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
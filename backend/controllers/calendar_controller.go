@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+	"github.com/gorilla/mux"
+)
+
+// Calendar window limits in days
+const (
+	defaultCalendarDays = 90
+	maxCalendarDays     = 365
+)
+
+// CalendarController handles release calendar requests
+type CalendarController struct {
+	calendarService *services.CalendarService
+	logger          *middleware.Logger
+}
+
+// NewCalendarController creates a new calendar controller
+func NewCalendarController(calendarService *services.CalendarService, logger *middleware.Logger) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+		logger:          logger,
+	}
+}
+
+// GetCalendar handles release calendar requests
+func (c *CalendarController) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	days, ok := parseCalendarDays(w, r)
+	if !ok {
+		return
+	}
+
+	// Get calendar
+	calendar, err := c.calendarService.GetCalendar(r.Context(), userID, days)
+	if err != nil {
+		c.logger.LogError(err, "GetCalendar", r)
+		http.Error(w, "Failed to get calendar", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(calendar, "Calendar retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCalendarFeed handles requests for the user's subscribable feed URL
+func (c *CalendarController) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	c.calendarFeed(w, r, false)
+}
+
+// RotateCalendarFeed handles replacing the user's feed URL, e.g. after it leaked
+func (c *CalendarController) RotateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	c.calendarFeed(w, r, true)
+}
+
+// calendarFeed returns the user's feed URLs, optionally with a new token
+func (c *CalendarController) calendarFeed(w http.ResponseWriter, r *http.Request, rotate bool) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get feed token
+	getToken := c.calendarService.GetFeedToken
+	if rotate {
+		getToken = c.calendarService.RotateFeedToken
+	}
+	token, createdAt, err := getToken(r.Context(), userID)
+	if err != nil {
+		c.logger.LogError(err, "CalendarFeed", r)
+		http.Error(w, "Failed to create calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	// Build URLs from the host the client used
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	path := fmt.Sprintf("/api/v1/calendar/%s.ics", token)
+	feed := models.CalendarFeed{
+		URL:       fmt.Sprintf("%s://%s%s", scheme, r.Host, path),
+		WebcalURL: fmt.Sprintf("webcal://%s%s", r.Host, path),
+		CreatedAt: createdAt,
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(feed, "Calendar feed retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCalendarICS handles iCalendar feed requests. The secret token in the URL
+// identifies the user, since calendar apps can't send headers.
+func (c *CalendarController) GetCalendarICS(w http.ResponseWriter, r *http.Request) {
+	// Get user from token
+	userID, exists := c.calendarService.GetFeedUser(r.Context(), mux.Vars(r)["token"])
+	if !exists {
+		http.Error(w, "Calendar not found", http.StatusNotFound)
+		return
+	}
+
+	// Get query parameters
+	days, ok := parseCalendarDays(w, r)
+	if !ok {
+		return
+	}
+
+	// Get calendar
+	calendar, err := c.calendarService.GetCalendar(r.Context(), userID, days)
+	if err != nil {
+		c.logger.LogError(err, "GetCalendarICS", r)
+		http.Error(w, "Failed to get calendar", http.StatusInternalServerError)
+		return
+	}
+
+	// Convert to iCalendar events
+	events := make([]utils.ICalEvent, 0, len(calendar.Events))
+	for _, event := range calendar.Events {
+		date, err := time.Parse("2006-01-02", event.Date)
+		if err != nil {
+			continue
+		}
+		events = append(events, utils.ICalEvent{
+			UID:         event.UID + "@movie-shows-discovery",
+			Date:        date,
+			Summary:     calendarEventSummary(event),
+			Description: calendarEventDescription(event),
+		})
+	}
+
+	// Send response
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="releases.ics"`)
+	w.Write(utils.BuildICalendar("Upcoming releases", events))
+}
+
+// parseCalendarDays reads the days query parameter, writing an error response
+// if it's invalid
+func parseCalendarDays(w http.ResponseWriter, r *http.Request) (int, bool) {
+	days := defaultCalendarDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxCalendarDays {
+			http.Error(w, fmt.Sprintf("Invalid days, must be between 1 and %d", maxCalendarDays), http.StatusBadRequest)
+			return 0, false
+		}
+		days = parsed
+	}
+	return days, true
+}
+
+// calendarEventSummary returns the title shown in calendar apps
+func calendarEventSummary(event models.CalendarEvent) string {
+	switch event.Type {
+	case models.CalendarEpisode:
+		summary := fmt.Sprintf("%s S%02dE%02d", event.Title, event.SeasonNumber, event.EpisodeNumber)
+		if event.EpisodeName != "" {
+			summary += ": " + event.EpisodeName
+		}
+		return summary
+	case models.CalendarTheatrical:
+		return event.Title + " in theaters"
+	case models.CalendarDigital:
+		return event.Title + " digital release"
+	case models.CalendarPhysical:
+		return event.Title + " on disc"
+	case models.CalendarPremiere:
+		return event.Title + " premiere"
+	default:
+		return event.Title
+	}
+}
+
+// calendarEventDescription explains why an event is in the user's calendar
+func calendarEventDescription(event models.CalendarEvent) string {
+	var lines []string
+	if event.Source == models.CalendarSourceFollowing && event.PersonName != "" {
+		lines = append(lines, "You follow "+event.PersonName)
+	} else {
+		lines = append(lines, "On your watchlist")
+	}
+	if event.Note != "" {
+		lines = append(lines, event.Note)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+// DiscoverController handles discover related HTTP requests
+type DiscoverController struct {
+	tmdbService     *services.TMDBService
+	settingsService *services.SettingsService
+	logger          *middleware.Logger
+}
+
+// NewDiscoverController creates a new discover controller
+func NewDiscoverController(tmdbService *services.TMDBService, settingsService *services.SettingsService, logger *middleware.Logger) *DiscoverController {
+	return &DiscoverController{
+		tmdbService:     tmdbService,
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// Discover handles discover requests. With only_my_services=true the results
+// are limited to titles included with the user's saved subscriptions.
+func (c *DiscoverController) Discover(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// Get query parameters
+	filter := models.DiscoverFilter{
+		MediaType: query.Get("media_type"),
+		SortBy:    query.Get("sort_by"),
+		Region:    strings.ToUpper(query.Get("region")),
+	}
+	if filter.MediaType == "" {
+		filter.MediaType = models.MediaTypeMovie
+	}
+	if filter.MediaType != models.MediaTypeMovie && filter.MediaType != models.MediaTypeTV {
+		http.Error(w, "Invalid media_type, must be movie or tv", http.StatusBadRequest)
+		return
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = "popularity.desc"
+	}
+
+	filter.Page, _ = strconv.Atoi(query.Get("page"))
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	genreIDs, err := parseIntList(query.Get("genres"))
+	if err != nil {
+		http.Error(w, "Invalid genres", http.StatusBadRequest)
+		return
+	}
+	filter.GenreIDs = genreIDs
+
+	providerIDs, err := parseIntList(query.Get("providers"))
+	if err != nil {
+		http.Error(w, "Invalid providers", http.StatusBadRequest)
+		return
+	}
+	filter.ProviderIDs = providerIDs
+
+	// Use the user's region and subscriptions; anonymous users get the defaults
+	userID := r.Header.Get("X-User-ID")
+	onlyMine := query.Get("only_my_services") == "true"
+	if onlyMine && userID == "" {
+		http.Error(w, "User ID is required for only_my_services", http.StatusBadRequest)
+		return
+	}
+	settings := c.settingsService.GetSettings(r.Context(), userID)
+	if filter.Region == "" {
+		filter.Region = settings.Region
+	}
+	if onlyMine {
+		if len(settings.ProviderIDs) == 0 {
+			http.Error(w, "No streaming services saved in settings", http.StatusBadRequest)
+			return
+		}
+		filter.ProviderIDs = settings.ProviderIDs
+	}
+	if !isValidRegion(filter.Region) {
+		http.Error(w, "Invalid region, must be an ISO 3166-1 country code", http.StatusBadRequest)
+		return
+	}
+
+	// Discover media
+	result, err := c.tmdbService.DiscoverMedia(r.Context(), filter)
+	if err != nil {
+		c.logger.LogError(err, "Discover", r)
+		http.Error(w, "Failed to discover media", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	meta := models.Meta{
+		Page:         result.Page,
+		PerPage:      20,
+		TotalPages:   result.TotalPages,
+		TotalResults: result.TotalResults,
+		HasNext:      result.Page < result.TotalPages,
+		HasPrev:      result.Page > 1,
+	}
+
+	response := models.NewPaginatedResponse(result.Results, meta, "Discover results retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseIntList parses a comma-separated list of positive integers
+func parseIntList(value string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return nil, strconv.ErrSyntax
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// ListController handles curated list requests such as upcoming movies and
+// shows on the air
+type ListController struct {
+	tmdbService     *services.TMDBService
+	settingsService *services.SettingsService
+	logger          *middleware.Logger
+}
+
+// NewListController creates a new list controller
+func NewListController(tmdbService *services.TMDBService, settingsService *services.SettingsService, logger *middleware.Logger) *ListController {
+	return &ListController{
+		tmdbService:     tmdbService,
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// GetMovieList handles upcoming, now playing, popular and top rated movie requests
+func (c *ListController) GetMovieList(w http.ResponseWriter, r *http.Request) {
+	c.getMediaList(w, r, models.MediaTypeMovie)
+}
+
+// GetTVList handles airing today, on the air, popular and top rated TV requests
+func (c *ListController) GetTVList(w http.ResponseWriter, r *http.Request) {
+	c.getMediaList(w, r, models.MediaTypeTV)
+}
+
+// getMediaList returns a page of a list. Movie lists use the region parameter,
+// the region saved by the user or the default region, in that order.
+func (c *ListController) getMediaList(w http.ResponseWriter, r *http.Request, mediaType string) {
+	// Get list from URL
+	list := mux.Vars(r)["list"]
+	if !services.IsMediaList(mediaType, list) {
+		http.Error(w, "Unknown list", http.StatusNotFound)
+		return
+	}
+
+	// Get query parameters
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	// Resolve region
+	region := ""
+	if services.IsRegionalList(mediaType, list) {
+		region = strings.ToUpper(r.URL.Query().Get("region"))
+		if region == "" {
+			if userID := r.Header.Get("X-User-ID"); userID != "" {
+				region = c.settingsService.GetSettings(r.Context(), userID).Region
+			} else {
+				region = config.AppConfig.TMDB.Region
+			}
+		}
+		if !isValidRegion(region) {
+			http.Error(w, "Invalid region, must be an ISO 3166-1 country code", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get list from TMDB
+	result, err := c.tmdbService.GetMediaList(r.Context(), mediaType, list, region, page)
+	if err != nil {
+		c.logger.LogError(err, "GetMediaList ("+mediaType+" "+list+")", r)
+		http.Error(w, "Failed to get list", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	meta := models.Meta{
+		Page:         result.Page,
+		PerPage:      20,
+		TotalPages:   result.TotalPages,
+		TotalResults: result.TotalResults,
+		HasNext:      result.Page < result.TotalPages,
+		HasPrev:      result.Page > 1,
+	}
+
+	response := models.NewMediaListResponse(result, mediaType, list, region, meta)
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -13,19 +13,21 @@ import (
 
 // MovieController handles movie-related HTTP requests
 type MovieController struct {
-	tmdbService      *services.TMDBService
-	omdbService      *services.OMDBService
-	logger           *middleware.Logger
-	watchlistService *services.WatchlistService
+	tmdbService        *services.TMDBService
+	omdbService        *services.OMDBService
+	logger             *middleware.Logger
+	watchlistService   *services.WatchlistService
+	searchIndexService *services.SearchIndexService
 }
 
 // NewMovieController creates a new movie controller
-func NewMovieController(tmdbService *services.TMDBService, omdbService *services.OMDBService, logger *middleware.Logger, watchlistService *services.WatchlistService) *MovieController {
+func NewMovieController(tmdbService *services.TMDBService, omdbService *services.OMDBService, logger *middleware.Logger, watchlistService *services.WatchlistService, searchIndexService *services.SearchIndexService) *MovieController {
 	return &MovieController{
-		tmdbService:      tmdbService,
-		omdbService:      omdbService,
-		logger:           logger,
-		watchlistService: watchlistService,
+		tmdbService:        tmdbService,
+		omdbService:        omdbService,
+		logger:             logger,
+		watchlistService:   watchlistService,
+		searchIndexService: searchIndexService,
 	}
 }
 
@@ -47,6 +49,9 @@ func (c *MovieController) SearchMovies(w http.ResponseWriter, r *http.Request) {
 	if perPage <= 0 {
 		perPage = 10 // default to 10
 	}
+	if perPage > 100 {
+		perPage = 100
+	}
 
 	includeAdult := r.URL.Query().Get("include_adult") == "true"
 	mediaType := r.URL.Query().Get("type")
@@ -54,6 +59,27 @@ func (c *MovieController) SearchMovies(w http.ResponseWriter, r *http.Request) {
 		mediaType = "all"
 	}
 
+	// Search the local catalog index instead of TMDB
+	if r.URL.Query().Get("source") == "local" {
+		hits, total := c.searchIndexService.Search(query, mediaType, page, perPage)
+		totalPages := (total + perPage - 1) / perPage
+
+		meta := models.Meta{
+			Page:         page,
+			PerPage:      perPage,
+			TotalPages:   totalPages,
+			TotalResults: total,
+			HasNext:      page < totalPages,
+			HasPrev:      page > 1,
+		}
+
+		response := models.NewSearchResponse(hits, query, meta)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	// Search movies and/or TV shows
 	result, err := c.tmdbService.SearchMedia(r.Context(), query, page, perPage, mediaType, includeAdult)
 	if err != nil {
@@ -225,13 +251,16 @@ func (c *MovieController) GetGenres(w http.ResponseWriter, r *http.Request) {
 
 // GetSimilarMovies handles similar movies requests
 func (c *MovieController) GetSimilarMovies(w http.ResponseWriter, r *http.Request) {
-	// Get movie ID from URL
-	vars := mux.Vars(r)
-	movieIDStr := vars["id"]
+	sendSimilarTitles(w, r, c.watchlistService, c.logger, models.MediaTypeMovie)
+}
 
-	movieID, err := strconv.Atoi(movieIDStr)
+// sendSimilarTitles responds with the titles most similar to a movie or TV show
+func sendSimilarTitles(w http.ResponseWriter, r *http.Request, watchlistService *services.WatchlistService, logger *middleware.Logger, mediaType string) {
+	// Get ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
 
@@ -241,16 +270,16 @@ func (c *MovieController) GetSimilarMovies(w http.ResponseWriter, r *http.Reques
 		limit = 10
 	}
 
-	// Get similar movies
-	similarMovies, err := c.watchlistService.GetSimilarMovies(r.Context(), movieID, limit)
+	// Get similar titles
+	similar, err := watchlistService.GetSimilarTitles(r.Context(), mediaType, id, limit)
 	if err != nil {
-		c.logger.LogError(err, "GetSimilarMovies", r)
-		http.Error(w, "Failed to get similar movies", http.StatusInternalServerError)
+		logger.LogError(err, "GetSimilarTitles", r)
+		http.Error(w, "Failed to get similar titles", http.StatusInternalServerError)
 		return
 	}
 
 	// Create response
-	response := models.NewSuccessResponse(similarMovies, "Similar movies retrieved successfully")
+	response := models.NewSuccessResponse(similar, "Similar titles retrieved successfully")
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+// NotificationController handles notification inbox related HTTP requests
+type NotificationController struct {
+	notificationService *services.NotificationService
+	logger              *middleware.Logger
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(notificationService *services.NotificationService, logger *middleware.Logger) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// GetNotifications handles notification inbox requests
+func (c *NotificationController) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	// Get notifications
+	notifications, unread := c.notificationService.GetNotifications(r.Context(), userID, unreadOnly)
+
+	// Create response
+	response := models.NewSuccessResponse(map[string]interface{}{
+		"notifications": notifications,
+		"unread_count":  unread,
+	}, "Notifications retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// MarkNotificationsRead handles marking notifications as read
+func (c *NotificationController) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body; an empty body marks everything as read
+	var request models.NotificationReadRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Mark as read
+	changed := c.notificationService.MarkRead(r.Context(), userID, request.IDs)
+
+	// Create response
+	response := models.NewSuccessResponse(map[string]interface{}{
+		"marked_read": changed,
+	}, "Notifications marked as read")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
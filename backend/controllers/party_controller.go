@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// partyHeartbeat is how often an idle event stream is kept alive
+const partyHeartbeat = 15 * time.Second
+
+// PartyController handles watch party requests
+type PartyController struct {
+	partyService *services.PartyService
+	logger       *middleware.Logger
+}
+
+// NewPartyController creates a new watch party controller
+func NewPartyController(partyService *services.PartyService, logger *middleware.Logger) *PartyController {
+	return &PartyController{
+		partyService: partyService,
+		logger:       logger,
+	}
+}
+
+// CreateParty handles requests to start a watch party
+func (c *PartyController) CreateParty(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.WatchPartyCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Create party
+	party, err := c.partyService.CreateParty(r.Context(), userID, request)
+	if err != nil {
+		c.partyError(w, r, err, "CreateParty", "Failed to create watch party")
+		return
+	}
+	setInviteURL(r, party)
+
+	// Create response
+	response := models.NewSuccessResponse(party, "Watch party created successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetParty handles requests for a watch party's candidates, participants
+// and current result
+func (c *PartyController) GetParty(w http.ResponseWriter, r *http.Request) {
+	party, err := c.partyService.GetParty(r.Context(), mux.Vars(r)["code"])
+	if err != nil {
+		c.partyError(w, r, err, "GetParty", "Failed to get watch party")
+		return
+	}
+	c.sendParty(w, r, party, "Watch party retrieved successfully")
+}
+
+// JoinParty handles requests to join a watch party through its invite link
+func (c *PartyController) JoinParty(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Join party
+	party, err := c.partyService.JoinParty(r.Context(), mux.Vars(r)["code"], userID)
+	if err != nil {
+		c.partyError(w, r, err, "JoinParty", "Failed to join watch party")
+		return
+	}
+	c.sendParty(w, r, party, "Joined watch party successfully")
+}
+
+// Vote handles a participant's ranking of a watch party's candidates
+func (c *PartyController) Vote(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.PartyVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Record vote
+	party, err := c.partyService.Vote(r.Context(), mux.Vars(r)["code"], userID, request)
+	if err != nil {
+		c.partyError(w, r, err, "Vote", "Failed to record vote")
+		return
+	}
+	c.sendParty(w, r, party, "Vote recorded successfully")
+}
+
+// CloseParty handles the host ending a watch party's voting
+func (c *PartyController) CloseParty(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Close party
+	party, err := c.partyService.CloseParty(r.Context(), mux.Vars(r)["code"], userID)
+	if err != nil {
+		c.partyError(w, r, err, "CloseParty", "Failed to close watch party")
+		return
+	}
+	c.sendParty(w, r, party, "Watch party closed successfully")
+}
+
+// StreamParty handles server-sent event streams of a watch party. A "party"
+// event with the full state is sent on connect and after every join, vote
+// and close, and the stream ends once the party closes or expires. The
+// invite code identifies the party, since EventSource can't send headers.
+func (c *PartyController) StreamParty(w http.ResponseWriter, r *http.Request) {
+	updates, unsubscribe, err := c.partyService.Subscribe(r.Context(), mux.Vars(r)["code"])
+	if err != nil {
+		c.partyError(w, r, err, "StreamParty", "Failed to stream watch party")
+		return
+	}
+	defer unsubscribe()
+
+	// Streams outlive the server's write timeout
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(partyHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case party, ok := <-updates:
+			if !ok {
+				return
+			}
+			setInviteURL(r, &party)
+			data, err := json.Marshal(party)
+			if err != nil {
+				c.logger.LogError(err, "StreamParty", r)
+				return
+			}
+			fmt.Fprintf(w, "event: party\ndata: %s\n\n", data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// sendParty writes a watch party response
+func (c *PartyController) sendParty(w http.ResponseWriter, r *http.Request, party *models.WatchParty, message string) {
+	setInviteURL(r, party)
+
+	// Create response
+	response := models.NewSuccessResponse(party, message)
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// partyError maps watch party errors to responses
+func (c *PartyController) partyError(w http.ResponseWriter, r *http.Request, err error, operation string, message string) {
+	switch {
+	case errors.Is(err, services.ErrPartyNotFound):
+		http.Error(w, "Watch party not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrPickWatchlistNotFound):
+		http.Error(w, "Watchlist not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrPartyForbidden):
+		http.Error(w, "Only the host can close the watch party", http.StatusForbidden)
+	case errors.Is(err, services.ErrPartyClosed):
+		http.Error(w, "Watch party is closed", http.StatusConflict)
+	case errors.Is(err, services.ErrInvalidParty), errors.Is(err, services.ErrInvalidPick):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		c.logger.LogError(err, operation, r)
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+// setInviteURL builds the party's invite link from the host the client used
+func setInviteURL(r *http.Request, party *models.WatchParty) {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	party.InviteURL = fmt.Sprintf("%s://%s/api/v1/parties/%s", scheme, r.Host, party.Code)
+}
@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// PeopleController handles person-related HTTP requests
+type PeopleController struct {
+	tmdbService   *services.TMDBService
+	followService *services.FollowService
+	logger        *middleware.Logger
+}
+
+// NewPeopleController creates a new people controller
+func NewPeopleController(tmdbService *services.TMDBService, followService *services.FollowService, logger *middleware.Logger) *PeopleController {
+	return &PeopleController{
+		tmdbService:   tmdbService,
+		followService: followService,
+		logger:        logger,
+	}
+}
+
+// SearchPeople handles person search requests
+func (c *PeopleController) SearchPeople(w http.ResponseWriter, r *http.Request) {
+	// Get query parameters
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "Query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	// Search people
+	result, err := c.tmdbService.SearchPeople(r.Context(), query, page)
+	if err != nil {
+		c.logger.LogError(err, "SearchPeople", r)
+		http.Error(w, "Failed to search people", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	meta := models.Meta{
+		Page:         result.Page,
+		PerPage:      20,
+		TotalPages:   result.TotalPages,
+		TotalResults: result.TotalResults,
+		HasNext:      result.Page < result.TotalPages,
+		HasPrev:      result.Page > 1,
+	}
+
+	response := models.NewSearchResponse(result.Results, query, meta)
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetPersonDetails handles person profile requests
+func (c *PeopleController) GetPersonDetails(w http.ResponseWriter, r *http.Request) {
+	// Get person ID from URL
+	vars := mux.Vars(r)
+	personID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get person details from TMDB
+	person, err := c.tmdbService.GetPersonDetails(r.Context(), personID)
+	if err != nil {
+		c.logger.LogError(err, "GetPersonDetails", r)
+		http.Error(w, "Failed to get person details", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(person, "Person details retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetPersonCredits handles filmography requests
+func (c *PeopleController) GetPersonCredits(w http.ResponseWriter, r *http.Request) {
+	// Get person ID from URL
+	vars := mux.Vars(r)
+	personID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	sortBy := r.URL.Query().Get("sort_by")
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	if sortBy != "date" && sortBy != "popularity" {
+		http.Error(w, "Invalid sort_by. Use 'date' or 'popularity'", http.StatusBadRequest)
+		return
+	}
+
+	mediaType := r.URL.Query().Get("type")
+	if mediaType == "" {
+		mediaType = "all"
+	}
+
+	// Get combined credits
+	credits, err := c.tmdbService.GetPersonCredits(r.Context(), personID, sortBy)
+	if err != nil {
+		c.logger.LogError(err, "GetPersonCredits", r)
+		http.Error(w, "Failed to get person credits", http.StatusInternalServerError)
+		return
+	}
+
+	// Filter by media type
+	if mediaType != "all" {
+		credits.Cast = filterCreditsByMediaType(credits.Cast, mediaType)
+		credits.Crew = filterCreditsByMediaType(credits.Crew, mediaType)
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(credits, "Person credits retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// filterCreditsByMediaType keeps only credits of the given media type
+func filterCreditsByMediaType(credits []models.PersonCredit, mediaType string) []models.PersonCredit {
+	filtered := []models.PersonCredit{}
+	for _, credit := range credits {
+		if credit.MediaType == mediaType {
+			filtered = append(filtered, credit)
+		}
+	}
+	return filtered
+}
+
+// FollowPerson handles following a person so their upcoming work appears in
+// the user's release calendar and their new projects trigger alerts
+func (c *PeopleController) FollowPerson(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get person ID from URL
+	vars := mux.Vars(r)
+	personID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	// Make sure the person exists
+	if _, err := c.tmdbService.GetPersonDetails(r.Context(), personID); err != nil {
+		c.logger.LogError(err, "FollowPerson", r)
+		http.Error(w, "Person not found", http.StatusNotFound)
+		return
+	}
+
+	// Follow person
+	settings, err := c.followService.Follow(r.Context(), userID, personID)
+	if err != nil {
+		c.logger.LogError(err, "FollowPerson", r)
+		http.Error(w, "Failed to follow person", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(settings, "Person followed successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UnfollowPerson handles unfollowing a person
+func (c *PeopleController) UnfollowPerson(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get person ID from URL
+	vars := mux.Vars(r)
+	personID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	// Unfollow person
+	settings, err := c.followService.Unfollow(r.Context(), userID, personID)
+	if err != nil {
+		c.logger.LogError(err, "UnfollowPerson", r)
+		http.Error(w, "Failed to unfollow person", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(settings, "Person unfollowed successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetFollowingFeed handles requests for new projects from people the user follows
+func (c *PeopleController) GetFollowingFeed(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	// Get feed
+	updates, total := c.followService.GetFeed(r.Context(), userID, page, perPage)
+
+	// Create response
+	totalPages := (total + perPage - 1) / perPage
+	meta := models.Meta{
+		Page:         page,
+		PerPage:      perPage,
+		TotalPages:   totalPages,
+		TotalResults: total,
+		HasNext:      page < totalPages,
+		HasPrev:      page > 1,
+	}
+
+	response := models.NewPaginatedResponse(updates, meta, "From people you follow")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
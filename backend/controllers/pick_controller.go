@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+// PickController handles "what should we watch tonight" requests
+type PickController struct {
+	pickService *services.PickService
+	logger      *middleware.Logger
+}
+
+// NewPickController creates a new pick controller
+func NewPickController(pickService *services.PickService, logger *middleware.Logger) *PickController {
+	return &PickController{
+		pickService: pickService,
+		logger:      logger,
+	}
+}
+
+// Pick handles requests for a shortlist of titles to watch tonight and a
+// random pick from it
+func (c *PickController) Pick(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.PickRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if request.MaxRuntime < 0 {
+		http.Error(w, "max_runtime must not be negative", http.StatusBadRequest)
+		return
+	}
+	if request.MinRating < 0 || request.MinRating > 10 {
+		http.Error(w, "min_rating must be between 0 and 10", http.StatusBadRequest)
+		return
+	}
+	if request.Limit <= 0 {
+		request.Limit = 10
+	}
+	if request.Limit > 50 {
+		request.Limit = 50
+	}
+
+	// Pick a title
+	result, err := c.pickService.Pick(r.Context(), userID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPick):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, services.ErrPickWatchlistNotFound):
+			http.Error(w, "Watchlist not found", http.StatusNotFound)
+		default:
+			c.logger.LogError(err, "Pick", r)
+			http.Error(w, "Failed to pick a title", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Create response
+	message := "Title picked successfully"
+	if result.Pick == nil {
+		message = "No titles match the constraints"
+	}
+	response := models.NewSuccessResponse(result, message)
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetMoods handles requests for the moods a pick can ask for or avoid
+func (c *PickController) GetMoods(w http.ResponseWriter, r *http.Request) {
+	// Create response
+	response := models.NewSuccessResponse(services.PickMoods(), "Moods retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
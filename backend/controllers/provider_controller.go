@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// ProviderController handles watch provider related HTTP requests
+type ProviderController struct {
+	tmdbService     *services.TMDBService
+	settingsService *services.SettingsService
+	logger          *middleware.Logger
+}
+
+// NewProviderController creates a new provider controller
+func NewProviderController(tmdbService *services.TMDBService, settingsService *services.SettingsService, logger *middleware.Logger) *ProviderController {
+	return &ProviderController{
+		tmdbService:     tmdbService,
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// GetMovieProviders handles movie watch provider requests
+func (c *ProviderController) GetMovieProviders(w http.ResponseWriter, r *http.Request) {
+	c.getWatchProviders(w, r, models.MediaTypeMovie)
+}
+
+// GetTVProviders handles TV show watch provider requests
+func (c *ProviderController) GetTVProviders(w http.ResponseWriter, r *http.Request) {
+	c.getWatchProviders(w, r, models.MediaTypeTV)
+}
+
+// getWatchProviders returns where a title can be watched. A region parameter,
+// or the region saved by the user, narrows the result to one country;
+// region=all returns every country.
+func (c *ProviderController) getWatchProviders(w http.ResponseWriter, r *http.Request, mediaType string) {
+	// Get ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	// Resolve region
+	region := strings.ToUpper(r.URL.Query().Get("region"))
+	if region == "" {
+		if userID := r.Header.Get("X-User-ID"); userID != "" {
+			region = c.settingsService.GetSettings(r.Context(), userID).Region
+		}
+	}
+	if region != "" && region != "ALL" && !isValidRegion(region) {
+		http.Error(w, "Invalid region, must be an ISO 3166-1 country code", http.StatusBadRequest)
+		return
+	}
+
+	// Get providers from TMDB
+	providers, err := c.tmdbService.GetWatchProviders(r.Context(), mediaType, id)
+	if err != nil {
+		c.logger.LogError(err, "GetWatchProviders ("+mediaType+")", r)
+		http.Error(w, "Failed to get watch providers", http.StatusInternalServerError)
+		return
+	}
+
+	// Narrow to a single region
+	if region != "" && region != "ALL" {
+		regional := *providers
+		regional.Results = map[string]models.RegionProviders{}
+		if result, exists := providers.Results[region]; exists {
+			regional.Results[region] = result
+		}
+		providers = &regional
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(providers, "Watch providers retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetProviders handles requests for the providers available in a region
+func (c *ProviderController) GetProviders(w http.ResponseWriter, r *http.Request) {
+	// Get query parameters
+	mediaType := r.URL.Query().Get("media_type")
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		http.Error(w, "Invalid media_type, must be movie or tv", http.StatusBadRequest)
+		return
+	}
+
+	region := strings.ToUpper(r.URL.Query().Get("region"))
+	if region == "" {
+		region = c.settingsService.GetSettings(r.Context(), r.Header.Get("X-User-ID")).Region
+	}
+	if !isValidRegion(region) {
+		http.Error(w, "Invalid region, must be an ISO 3166-1 country code", http.StatusBadRequest)
+		return
+	}
+
+	// Get providers
+	providers, err := c.tmdbService.GetProviders(r.Context(), mediaType, region)
+	if err != nil {
+		c.logger.LogError(err, "GetProviders", r)
+		http.Error(w, "Failed to get providers", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(providers, "Providers retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// isValidRegion checks for a two-letter ISO 3166-1 country code
+func isValidRegion(region string) bool {
+	if len(region) != 2 {
+		return false
+	}
+	for _, r := range region {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+// SettingsController handles user settings related HTTP requests
+type SettingsController struct {
+	settingsService *services.SettingsService
+	logger          *middleware.Logger
+}
+
+// NewSettingsController creates a new settings controller
+func NewSettingsController(settingsService *services.SettingsService, logger *middleware.Logger) *SettingsController {
+	return &SettingsController{
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// GetSettings handles user settings requests
+func (c *SettingsController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get settings
+	settings := c.settingsService.GetSettings(r.Context(), userID)
+
+	// Create response
+	response := models.NewSuccessResponse(settings, "Settings retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateSettings handles user settings updates
+func (c *SettingsController) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.UserSettingsUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if request.Region != nil && !isValidRegion(strings.ToUpper(*request.Region)) {
+		http.Error(w, "Invalid region, must be an ISO 3166-1 country code", http.StatusBadRequest)
+		return
+	}
+	for _, id := range request.ProviderIDs {
+		if id <= 0 {
+			http.Error(w, "Invalid provider ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Update settings
+	settings := c.settingsService.UpdateSettings(r.Context(), userID, request)
+
+	// Create response
+	response := models.NewSuccessResponse(settings, "Settings updated successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+)
+
+// SuggestController handles typeahead requests
+type SuggestController struct {
+	suggestService *services.SuggestService
+	logger         *middleware.Logger
+}
+
+// NewSuggestController creates a new suggest controller
+func NewSuggestController(suggestService *services.SuggestService, logger *middleware.Logger) *SuggestController {
+	return &SuggestController{
+		suggestService: suggestService,
+		logger:         logger,
+	}
+}
+
+// GetSuggestions handles typeahead suggestion requests
+func (c *SuggestController) GetSuggestions(w http.ResponseWriter, r *http.Request) {
+	// Get query parameters
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "Query parameter 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 8
+	}
+	if limit > 20 {
+		limit = 20
+	}
+
+	var types []string
+	if typesParam := r.URL.Query().Get("types"); typesParam != "" {
+		types = strings.Split(typesParam, ",")
+	}
+
+	// User ID is optional and only used to boost watchlisted titles
+	userID := r.Header.Get("X-User-ID")
+
+	// Get suggestions from the local index
+	suggestions := c.suggestService.Suggest(r.Context(), userID, query, limit, types)
+
+	// Create response
+	response := models.NewSuccessResponse(suggestions, "Suggestions retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -3,24 +3,30 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+	"github.com/gorilla/mux"
 )
 
 // TrendingController handles trending content requests
 type TrendingController struct {
-	tmdbService *services.TMDBService
-	logger      *middleware.Logger
+	tmdbService     *services.TMDBService
+	snapshotService *services.TrendingSnapshotService
+	logger          *middleware.Logger
 }
 
 // NewTrendingController creates a new trending controller
-func NewTrendingController(tmdbService *services.TMDBService, logger *middleware.Logger) *TrendingController {
+func NewTrendingController(tmdbService *services.TMDBService, snapshotService *services.TrendingSnapshotService, logger *middleware.Logger) *TrendingController {
 	return &TrendingController{
-		tmdbService: tmdbService,
-		logger:      logger,
+		tmdbService:     tmdbService,
+		snapshotService: snapshotService,
+		logger:          logger,
 	}
 }
 
@@ -75,27 +81,61 @@ func (c *TrendingController) GetTrending(w http.ResponseWriter, r *http.Request)
 
 // GetTrendingByGenre handles trending content by genre
 func (c *TrendingController) GetTrendingByGenre(w http.ResponseWriter, r *http.Request) {
-	// Get genre ID from URL
-	genreIDStr := r.URL.Query().Get("genre_id")
-	genreID, err := strconv.Atoi(genreIDStr)
-	if err != nil {
+	// Get genre IDs; genre_id is kept for older clients
+	genres := r.URL.Query().Get("genres")
+	if genres == "" {
+		genres = r.URL.Query().Get("genre_id")
+	}
+	genreIDs, err := parseIntList(genres)
+	if err != nil || len(genreIDs) == 0 {
 		http.Error(w, "Invalid genre ID", http.StatusBadRequest)
 		return
 	}
 
 	// Get query parameters
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	if page <= 0 {
-		page = 1
+	timeframe := r.URL.Query().Get("timeframe")
+	if timeframe == "" {
+		timeframe = "week"
+	}
+	if timeframe != "day" && timeframe != "week" {
+		http.Error(w, "Invalid timeframe. Use 'day' or 'week'", http.StatusBadRequest)
+		return
+	}
+
+	mediaType := r.URL.Query().Get("type")
+	if mediaType == "" {
+		mediaType = "all"
+	}
+	if mediaType != "all" && mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		http.Error(w, "Invalid type. Use 'movie', 'tv' or 'all'", http.StatusBadRequest)
+		return
+	}
+
+	match := r.URL.Query().Get("match")
+	if match != "" && match != "any" && match != "all" {
+		http.Error(w, "Invalid match. Use 'any' or 'all'", http.StatusBadRequest)
+		return
 	}
 
 	sortBy := r.URL.Query().Get("sort_by")
-	if sortBy == "" {
-		sortBy = "popularity.desc"
+	if sortBy != "" && !services.IsTrendingSort(sortBy) {
+		http.Error(w, "Invalid sort_by. Use popularity, vote_average or release_date with .asc or .desc", http.StatusBadRequest)
+		return
 	}
 
-	// Get trending movies by genre
-	result, err := c.tmdbService.GetMoviesByGenre(r.Context(), genreID, page, sortBy)
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	// Get trending titles in the genres
+	results, err := c.tmdbService.GetTrendingByGenre(r.Context(), models.TrendingGenreFilter{
+		GenreIDs:  genreIDs,
+		MatchAll:  match == "all",
+		Timeframe: timeframe,
+		MediaType: mediaType,
+		SortBy:    sortBy,
+	})
 	if err != nil {
 		c.logger.LogError(err, "GetTrendingByGenre", r)
 		http.Error(w, "Failed to get trending content by genre", http.StatusInternalServerError)
@@ -103,16 +143,8 @@ func (c *TrendingController) GetTrendingByGenre(w http.ResponseWriter, r *http.R
 	}
 
 	// Create response
-	meta := models.Meta{
-		Page:         result.Page,
-		PerPage:      20,
-		TotalPages:   result.TotalPages,
-		TotalResults: result.TotalResults,
-		HasNext:      result.Page < result.TotalPages,
-		HasPrev:      result.Page > 1,
-	}
-
-	response := models.NewPaginatedResponse(result.Results, meta, "Trending content by genre retrieved successfully")
+	paged, meta := utils.PaginateResults(results, page, 20)
+	response := models.NewPaginatedResponse(paged, meta, "Trending content by genre retrieved successfully")
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
@@ -144,6 +176,20 @@ func (c *TrendingController) GetTrendingStats(w http.ResponseWriter, r *http.Req
 		"weekly_top_movies":     weekTrending.Results[:min(5, len(weekTrending.Results))],
 	}
 
+	// Add movers and staying power from recorded snapshots once there are any
+	movers := make(map[string]*models.TrendingMovers)
+	longest := make(map[string][]models.TrendingHistory)
+	for _, mediaType := range []string{models.MediaTypeMovie, models.MediaTypeTV} {
+		if dayMovers, err := c.snapshotService.GetMovers(r.Context(), mediaType, "day", 24*time.Hour); err == nil {
+			movers[mediaType] = dayMovers
+		}
+		if histories, err := c.snapshotService.GetLongestTrending(r.Context(), mediaType, "day", 5); err == nil {
+			longest[mediaType] = histories
+		}
+	}
+	stats["movers_since_yesterday"] = movers
+	stats["longest_trending"] = longest
+
 	// Create response
 	response := models.NewSuccessResponse(stats, "Trending stats retrieved successfully")
 
@@ -154,46 +200,94 @@ func (c *TrendingController) GetTrendingStats(w http.ResponseWriter, r *http.Req
 
 // GetTrendingGenres handles trending genres requests
 func (c *TrendingController) GetTrendingGenres(w http.ResponseWriter, r *http.Request) {
-	// Get all genres
-	genres, err := c.tmdbService.GetGenres(r.Context())
-	if err != nil {
-		c.logger.LogError(err, "GetTrendingGenres", r)
-		http.Error(w, "Failed to get trending genres", http.StatusInternalServerError)
+	// Get query parameters
+	timeframe := r.URL.Query().Get("timeframe")
+	if timeframe == "" {
+		timeframe = "week"
+	}
+	if timeframe != "day" && timeframe != "week" {
+		http.Error(w, "Invalid timeframe. Use 'day' or 'week'", http.StatusBadRequest)
 		return
 	}
 
-	// Get trending movies to analyze popular genres
-	trending, err := c.tmdbService.GetTrendingMedia(r.Context(), "week", 1, "all")
-	if err != nil {
-		c.logger.LogError(err, "GetTrendingGenres - trending", r)
-		http.Error(w, "Failed to get trending genres", http.StatusInternalServerError)
+	mediaTypes := []string{models.MediaTypeMovie, models.MediaTypeTV}
+	switch mediaType := r.URL.Query().Get("type"); mediaType {
+	case "", "all":
+	case models.MediaTypeMovie, models.MediaTypeTV:
+		mediaTypes = []string{mediaType}
+	default:
+		http.Error(w, "Invalid type. Use 'movie', 'tv' or 'all'", http.StatusBadRequest)
 		return
 	}
 
-	// Count genre occurrences in trending movies
+	points, _ := strconv.Atoi(r.URL.Query().Get("points"))
+	if points <= 0 {
+		points = 24
+	}
+
+	// Get genres and trending titles for each media type
+	genres := []models.Genre{}
+	seenGenres := make(map[int]bool)
 	genreCounts := make(map[int]int)
-	for _, movie := range trending.Results {
-		for _, genreID := range movie.GenreIDs {
-			genreCounts[genreID]++
+	total := 0
+	for _, mediaType := range mediaTypes {
+		var mediaGenres []models.Genre
+		var err error
+		if mediaType == models.MediaTypeTV {
+			mediaGenres, err = c.tmdbService.GetTVGenres(r.Context())
+		} else {
+			mediaGenres, err = c.tmdbService.GetGenres(r.Context())
+		}
+		if err != nil {
+			c.logger.LogError(err, "GetTrendingGenres", r)
+			http.Error(w, "Failed to get trending genres", http.StatusInternalServerError)
+			return
+		}
+		for _, genre := range mediaGenres {
+			if !seenGenres[genre.ID] {
+				seenGenres[genre.ID] = true
+				genres = append(genres, genre)
+			}
 		}
-	}
 
-	// Create trending genres response
-	type TrendingGenre struct {
-		Genre   models.Genre `json:"genre"`
-		Count   int          `json:"count"`
-		Popular bool         `json:"popular"`
+		pool, err := c.tmdbService.GetTrendingPool(r.Context(), mediaType, timeframe)
+		if err != nil {
+			c.logger.LogError(err, "GetTrendingGenres - trending", r)
+			http.Error(w, "Failed to get trending genres", http.StatusInternalServerError)
+			return
+		}
+		total += len(pool)
+		for _, media := range pool {
+			for _, genreID := range media.GenreIDs {
+				genreCounts[genreID]++
+			}
+		}
 	}
 
-	var trendingGenres []TrendingGenre
+	// Get each genre's share of the recorded top 20 over time
+	history := c.snapshotService.GetGenreShareHistory(r.Context(), mediaTypes, timeframe, points)
+
+	// Create trending genres response, biggest share first
+	trendingGenres := make([]models.TrendingGenre, 0, len(genres))
 	for _, genre := range genres {
-		count := genreCounts[genre.ID]
-		trendingGenres = append(trendingGenres, TrendingGenre{
+		trendingGenre := models.TrendingGenre{
 			Genre:   genre,
-			Count:   count,
-			Popular: count >= 3, // Consider popular if appears in 3+ trending movies
-		})
+			Count:   genreCounts[genre.ID],
+			History: history[genre.ID],
+		}
+		if total > 0 {
+			trendingGenre.Share = float64(trendingGenre.Count) / float64(total)
+		}
+		// Consider popular if at least one in ten trending titles has the genre
+		trendingGenre.Popular = trendingGenre.Share >= 0.1
+		if trendingGenre.History == nil {
+			trendingGenre.History = []models.TrendingGenrePoint{}
+		}
+		trendingGenres = append(trendingGenres, trendingGenre)
 	}
+	sort.SliceStable(trendingGenres, func(i, j int) bool {
+		return trendingGenres[i].Count > trendingGenres[j].Count
+	})
 
 	// Create response
 	response := models.NewSuccessResponse(trendingGenres, "Trending genres retrieved successfully")
@@ -203,6 +297,121 @@ func (c *TrendingController) GetTrendingGenres(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetTrendingSnapshots handles requests for the recorded trending charts
+func (c *TrendingController) GetTrendingSnapshots(w http.ResponseWriter, r *http.Request) {
+	// Get query parameters
+	mediaType, timeframe, ok := parseTrendingChart(w, r)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 24
+	}
+
+	// Get snapshots
+	snapshots := c.snapshotService.GetSnapshots(r.Context(), mediaType, timeframe, limit)
+
+	// Create response
+	response := models.NewSuccessResponse(snapshots, "Trending snapshots retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTrendingMovers handles requests for the biggest trending movers
+func (c *TrendingController) GetTrendingMovers(w http.ResponseWriter, r *http.Request) {
+	// Get query parameters
+	mediaType, timeframe, ok := parseTrendingChart(w, r)
+	if !ok {
+		return
+	}
+
+	since := 24 * time.Hour
+	switch r.URL.Query().Get("since") {
+	case "", "yesterday":
+	case "last_week":
+		since = 7 * 24 * time.Hour
+	default:
+		http.Error(w, "Invalid since. Use 'yesterday' or 'last_week'", http.StatusBadRequest)
+		return
+	}
+
+	// Compare snapshots
+	movers, err := c.snapshotService.GetMovers(r.Context(), mediaType, timeframe, since)
+	if err != nil {
+		http.Error(w, "Not enough trending history yet", http.StatusNotFound)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(movers, "Trending movers retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTitleTrendingHistory handles requests for a title's trending history
+func (c *TrendingController) GetTitleTrendingHistory(w http.ResponseWriter, r *http.Request) {
+	// Get media type and timeframe
+	mediaType, timeframe, ok := parseTrendingChart(w, r)
+	if !ok {
+		return
+	}
+
+	// Get ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get history
+	history, err := c.snapshotService.GetTitleHistory(r.Context(), mediaType, timeframe, id)
+	if err != nil {
+		http.Error(w, "Not enough trending history yet", http.StatusNotFound)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(history, "Trending history retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseTrendingChart reads the type and timeframe query parameters, writing an
+// error response if either is invalid. A type in the route takes the place of
+// the query parameter.
+func parseTrendingChart(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	mediaType, ok := mux.Vars(r)["type"]
+	if !ok {
+		mediaType = r.URL.Query().Get("type")
+	}
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		http.Error(w, "Invalid type. Use 'movie' or 'tv'", http.StatusBadRequest)
+		return "", "", false
+	}
+
+	timeframe := r.URL.Query().Get("timeframe")
+	if timeframe == "" {
+		timeframe = "day"
+	}
+	if timeframe != "day" && timeframe != "week" {
+		http.Error(w, "Invalid timeframe. Use 'day' or 'week'", http.StatusBadRequest)
+		return "", "", false
+	}
+
+	return mediaType, timeframe, true
+}
+
 // Helper function to get minimum of two integers
 func min(a, b int) int {
 	if a < b {
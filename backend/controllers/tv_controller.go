@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/middleware"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/services"
+	"github.com/gorilla/mux"
+)
+
+// TVController handles TV show related HTTP requests
+type TVController struct {
+	tmdbService      *services.TMDBService
+	watchlistService *services.WatchlistService
+	logger           *middleware.Logger
+}
+
+// NewTVController creates a new TV controller
+func NewTVController(tmdbService *services.TMDBService, watchlistService *services.WatchlistService, logger *middleware.Logger) *TVController {
+	return &TVController{
+		tmdbService:      tmdbService,
+		watchlistService: watchlistService,
+		logger:           logger,
+	}
+}
+
+// GetTVDetails handles TV show details requests
+func (c *TVController) GetTVDetails(w http.ResponseWriter, r *http.Request) {
+	// Get TV show ID from URL
+	vars := mux.Vars(r)
+	tvID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid TV show ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get TV show details from TMDB
+	tv, err := c.tmdbService.GetTVDetails(r.Context(), tvID)
+	if err != nil {
+		c.logger.LogError(err, "GetTVDetails", r)
+		http.Error(w, "Failed to get TV show details", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(tv, "TV show details retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetSimilarTVShows handles similar TV shows requests
+func (c *TVController) GetSimilarTVShows(w http.ResponseWriter, r *http.Request) {
+	sendSimilarTitles(w, r, c.watchlistService, c.logger, models.MediaTypeTV)
+}
+
+// GetTVSeason handles TV season requests
+func (c *TVController) GetTVSeason(w http.ResponseWriter, r *http.Request) {
+	// Get TV show ID and season number from URL
+	vars := mux.Vars(r)
+	tvID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid TV show ID", http.StatusBadRequest)
+		return
+	}
+
+	seasonNumber, err := strconv.Atoi(vars["season"])
+	if err != nil {
+		http.Error(w, "Invalid season number", http.StatusBadRequest)
+		return
+	}
+
+	// Get season from TMDB
+	season, err := c.tmdbService.GetTVSeason(r.Context(), tvID, seasonNumber)
+	if err != nil {
+		c.logger.LogError(err, "GetTVSeason", r)
+		http.Error(w, "Failed to get TV season", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(season, "TV season retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTVEpisode handles TV episode requests
+func (c *TVController) GetTVEpisode(w http.ResponseWriter, r *http.Request) {
+	// Get TV show ID, season and episode numbers from URL
+	vars := mux.Vars(r)
+	tvID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid TV show ID", http.StatusBadRequest)
+		return
+	}
+
+	seasonNumber, err := strconv.Atoi(vars["season"])
+	if err != nil {
+		http.Error(w, "Invalid season number", http.StatusBadRequest)
+		return
+	}
+
+	episodeNumber, err := strconv.Atoi(vars["episode"])
+	if err != nil {
+		http.Error(w, "Invalid episode number", http.StatusBadRequest)
+		return
+	}
+
+	// Get episode from TMDB
+	episode, err := c.tmdbService.GetTVEpisode(r.Context(), tvID, seasonNumber, episodeNumber)
+	if err != nil {
+		c.logger.LogError(err, "GetTVEpisode", r)
+		http.Error(w, "Failed to get TV episode", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(episode, "TV episode retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetTVGenres handles TV genre list requests
+func (c *TVController) GetTVGenres(w http.ResponseWriter, r *http.Request) {
+	// Get genres
+	genres, err := c.tmdbService.GetTVGenres(r.Context())
+	if err != nil {
+		c.logger.LogError(err, "GetTVGenres", r)
+		http.Error(w, "Failed to get TV genres", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(genres, "TV genres retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
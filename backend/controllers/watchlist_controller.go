@@ -82,7 +82,7 @@ func (c *WatchlistController) GetWatchlist(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(response)
 }
 
-// AddToWatchlist handles adding movies to watchlist
+// AddToWatchlist handles adding movies and TV shows to watchlist
 func (c *WatchlistController) AddToWatchlist(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from request
 	userID := r.Header.Get("X-User-ID")
@@ -98,6 +98,12 @@ func (c *WatchlistController) AddToWatchlist(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Validate media type
+	if request.MediaType != "" && request.MediaType != "movie" && request.MediaType != "tv" {
+		http.Error(w, "Invalid media_type, must be movie or tv", http.StatusBadRequest)
+		return
+	}
+
 	// Add to watchlist
 	item, err := c.watchlistService.AddToWatchlist(r.Context(), userID, request)
 	if err != nil {
@@ -107,7 +113,7 @@ func (c *WatchlistController) AddToWatchlist(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Create response
-	response := models.NewSuccessResponse(item, "Movie added to watchlist successfully")
+	response := models.NewSuccessResponse(item, "Added to watchlist successfully")
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
@@ -155,7 +161,7 @@ func (c *WatchlistController) UpdateWatchlistItem(w http.ResponseWriter, r *http
 	json.NewEncoder(w).Encode(response)
 }
 
-// RemoveFromWatchlist handles removing movies from watchlist
+// RemoveFromWatchlist handles removing movies and TV shows from watchlist
 func (c *WatchlistController) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from request
 	userID := r.Header.Get("X-User-ID")
@@ -227,9 +233,12 @@ func (c *WatchlistController) GetRecommendations(w http.ResponseWriter, r *http.
 	if limit <= 0 {
 		limit = 10
 	}
+	if limit > 50 {
+		limit = 50
+	}
 
 	// Get recommendations
-	recommendations, err := c.watchlistService.GetRecommendations(r.Context(), userID, limit)
+	recommendations, experiment, err := c.watchlistService.GetRecommendations(r.Context(), userID, limit)
 	if err != nil {
 		c.logger.LogError(err, "GetRecommendations", r)
 		http.Error(w, "Failed to get recommendations", http.StatusInternalServerError)
@@ -246,7 +255,219 @@ func (c *WatchlistController) GetRecommendations(w http.ResponseWriter, r *http.
 		HasPrev:      false,
 	}
 
-	response := models.NewRecommendationResponse(recommendations, userID, meta)
+	response := models.NewRecommendationResponse(recommendations, userID, experiment, meta)
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRecommendationFeedback handles requests for the user's recommendation feedback
+func (c *WatchlistController) GetRecommendationFeedback(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get feedback
+	feedback := c.watchlistService.GetRecommendationFeedback(r.Context(), userID)
+
+	// Create response
+	response := models.NewSuccessResponse(feedback, "Recommendation feedback retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// AddRecommendationFeedback handles marking a recommendation as not
+// interesting, already seen or worth more of
+func (c *WatchlistController) AddRecommendationFeedback(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.RecommendationFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if request.MediaType != "" && request.MediaType != "movie" && request.MediaType != "tv" {
+		http.Error(w, "Invalid media_type, must be movie or tv", http.StatusBadRequest)
+		return
+	}
+	if request.MediaID <= 0 {
+		http.Error(w, "media_id is required", http.StatusBadRequest)
+		return
+	}
+	switch request.Feedback {
+	case models.FeedbackNotInterested, models.FeedbackAlreadySeen, models.FeedbackMoreLikeThis:
+	default:
+		http.Error(w, "Invalid feedback, must be not_interested, already_seen or more_like_this", http.StatusBadRequest)
+		return
+	}
+
+	// Record feedback
+	feedback, err := c.watchlistService.AddRecommendationFeedback(r.Context(), userID, request)
+	if err != nil {
+		c.logger.LogError(err, "AddRecommendationFeedback", r)
+		http.Error(w, "Failed to save recommendation feedback", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(feedback, "Recommendation feedback saved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RemoveRecommendationFeedback handles undoing feedback on a recommendation
+func (c *WatchlistController) RemoveRecommendationFeedback(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get query parameters
+	mediaType := r.URL.Query().Get("media_type")
+	if mediaType != "" && mediaType != "movie" && mediaType != "tv" {
+		http.Error(w, "Invalid media_type, must be movie or tv", http.StatusBadRequest)
+		return
+	}
+	mediaID, err := strconv.Atoi(r.URL.Query().Get("media_id"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	// Remove feedback
+	if err := c.watchlistService.RemoveRecommendationFeedback(r.Context(), userID, mediaType, mediaID); err != nil {
+		c.logger.LogError(err, "RemoveRecommendationFeedback", r)
+		http.Error(w, "Recommendation feedback not found", http.StatusNotFound)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(nil, "Recommendation feedback removed successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetItemProgress handles TV episode progress requests for a watchlist item
+func (c *WatchlistController) GetItemProgress(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get item ID from URL
+	itemID, err := strconv.Atoi(r.URL.Query().Get("item_id"))
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Get progress
+	progress, err := c.watchlistService.GetItemProgress(r.Context(), userID, itemID)
+	if err != nil {
+		c.logger.LogError(err, "GetItemProgress", r)
+		http.Error(w, "Failed to get watch progress", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(progress, "Watch progress retrieved successfully")
+
+	// Send response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// MarkEpisodeWatched handles marking a single episode as watched
+func (c *WatchlistController) MarkEpisodeWatched(w http.ResponseWriter, r *http.Request) {
+	c.markProgress(w, r, "episode")
+}
+
+// MarkSeasonWatched handles marking a whole season as watched
+func (c *WatchlistController) MarkSeasonWatched(w http.ResponseWriter, r *http.Request) {
+	c.markProgress(w, r, "season")
+}
+
+// MarkShowWatched handles marking a whole show as watched
+func (c *WatchlistController) MarkShowWatched(w http.ResponseWriter, r *http.Request) {
+	c.markProgress(w, r, "show")
+}
+
+// markProgress updates episode progress for an episode, season or show
+func (c *WatchlistController) markProgress(w http.ResponseWriter, r *http.Request, scope string) {
+	// Get user ID from request
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Get item ID from URL
+	itemID, err := strconv.Atoi(r.URL.Query().Get("item_id"))
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var request models.EpisodeProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	watched := true
+	if request.Watched != nil {
+		watched = *request.Watched
+	}
+
+	// Update progress
+	var progress *models.TVProgress
+	switch scope {
+	case "episode":
+		if request.SeasonNumber < 0 || request.EpisodeNumber <= 0 {
+			http.Error(w, "season_number and episode_number are required", http.StatusBadRequest)
+			return
+		}
+		progress, err = c.watchlistService.MarkEpisodeWatched(r.Context(), userID, itemID, request.SeasonNumber, request.EpisodeNumber, watched)
+	case "season":
+		if request.SeasonNumber < 0 {
+			http.Error(w, "Invalid season_number", http.StatusBadRequest)
+			return
+		}
+		progress, err = c.watchlistService.MarkSeasonWatched(r.Context(), userID, itemID, request.SeasonNumber, watched)
+	default:
+		progress, err = c.watchlistService.MarkShowWatched(r.Context(), userID, itemID, watched)
+	}
+	if err != nil {
+		c.logger.LogError(err, "MarkProgress ("+scope+")", r)
+		http.Error(w, "Failed to update watch progress", http.StatusInternalServerError)
+		return
+	}
+
+	// Create response
+	response := models.NewSuccessResponse(progress, "Watch progress updated successfully")
 
 	// Send response
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Calendar event types
+const (
+	CalendarTheatrical = "theatrical"
+	CalendarDigital    = "digital"
+	CalendarPhysical   = "physical"
+	CalendarEpisode    = "episode"
+	CalendarPremiere   = "premiere"
+)
+
+// Calendar event sources
+const (
+	CalendarSourceWatchlist = "watchlist"
+	CalendarSourceFollowing = "following"
+)
+
+// TMDB release types
+const (
+	ReleaseTypePremiere          = 1
+	ReleaseTypeTheatricalLimited = 2
+	ReleaseTypeTheatrical        = 3
+	ReleaseTypeDigital           = 4
+	ReleaseTypePhysical          = 5
+	ReleaseTypeTV                = 6
+)
+
+// ReleaseDate represents a movie's release in one country
+type ReleaseDate struct {
+	Type          int    `json:"type"`
+	Date          string `json:"date"` // YYYY-MM-DD
+	Certification string `json:"certification,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// CalendarEvent represents an upcoming release or episode air date
+type CalendarEvent struct {
+	UID           string `json:"uid"`
+	Date          string `json:"date"` // YYYY-MM-DD
+	Type          string `json:"type"`
+	Source        string `json:"source"`
+	MediaType     string `json:"media_type"`
+	MediaID       int    `json:"media_id"`
+	Title         string `json:"title"`
+	PosterPath    string `json:"poster_path,omitempty"`
+	SeasonNumber  int    `json:"season_number,omitempty"`
+	EpisodeNumber int    `json:"episode_number,omitempty"`
+	EpisodeName   string `json:"episode_name,omitempty"`
+	PersonID      int    `json:"person_id,omitempty"`
+	PersonName    string `json:"person_name,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// Calendar represents a user's upcoming releases
+type Calendar struct {
+	UserID string          `json:"user_id"`
+	Region string          `json:"region"`
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Events []CalendarEvent `json:"events"`
+}
+
+// CalendarFeed represents a user's subscribable calendar feed
+type CalendarFeed struct {
+	URL       string    `json:"url"`
+	WebcalURL string    `json:"webcal_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
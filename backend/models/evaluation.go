@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// WatchlistExport is a snapshot of every user's watchlist items, the input
+// of offline recommendation evaluation
+type WatchlistExport struct {
+	ExportedAt time.Time                  `json:"exported_at"`
+	Watchlists map[string][]WatchlistItem `json:"watchlists"` // userID -> items
+}
+
+// RecommenderEvaluation is how well a recommendation strategy predicted the
+// titles users went on to add and like after a time split
+type RecommenderEvaluation struct {
+	Strategy  string  `json:"strategy"`
+	K         int     `json:"k"`
+	Users     int     `json:"users"`     // users with held out titles they liked
+	Precision float64 `json:"precision"` // at K, averaged over users
+	Recall    float64 `json:"recall"`    // at K, averaged over users
+	NDCG      float64 `json:"ndcg"`      // at K, averaged over users
+	Coverage  float64 `json:"coverage"`  // share of the training catalog recommended to anyone
+	Novelty   float64 `json:"novelty"`   // mean self-information of recommended titles, in bits
+	Duration  string  `json:"duration"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// ExperimentVariant is one arm of a recommendation experiment
+type ExperimentVariant struct {
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"` // recommender strategy the arm is served
+	Weight   int    `json:"weight"`   // share of users, relative to the other arms
+}
+
+// ExperimentAssignment is the variant of an experiment a user is bucketed
+// into
+type ExperimentAssignment struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
+	Strategy   string `json:"strategy"`
+}
+
+// ExperimentReport is how each arm of an experiment converted
+type ExperimentReport struct {
+	Experiment string                    `json:"experiment"`
+	Active     bool                      `json:"active"`
+	StartedAt  time.Time                 `json:"started_at"`
+	Variants   []ExperimentVariantReport `json:"variants"`
+}
+
+// ExperimentVariantReport is how one arm of an experiment converted
+type ExperimentVariantReport struct {
+	Variant           string `json:"variant"`
+	Strategy          string `json:"strategy"`
+	Users             int    `json:"users"`              // users shown recommendations
+	Impressions       int    `json:"impressions"`        // recommendations shown, counting repeat views
+	UniqueImpressions int    `json:"unique_impressions"` // distinct titles shown per user
+	Adds              int    `json:"adds"`               // shown titles users went on to add to their watchlist
+	ConvertedUsers    int    `json:"converted_users"`    // users who added at least one shown title
+	// ConversionRate is adds per unique impression and UserConversionRate the
+	// share of users who converted
+	ConversionRate     float64 `json:"conversion_rate"`
+	UserConversionRate float64 `json:"user_conversion_rate"`
+}
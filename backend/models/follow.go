@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Person update types
+const (
+	PersonUpdateAnnounced = "announced"
+	PersonUpdateReleased  = "released"
+)
+
+// PersonUpdate represents a new project in the filmography of a followed
+// person, either newly announced or newly released
+type PersonUpdate struct {
+	ID                string    `json:"id"`
+	Type              string    `json:"type"`
+	PersonID          int       `json:"person_id"`
+	PersonName        string    `json:"person_name"`
+	PersonProfilePath string    `json:"person_profile_path,omitempty"`
+	MediaType         string    `json:"media_type"`
+	MediaID           int       `json:"media_id"`
+	Title             string    `json:"title"`
+	PosterPath        string    `json:"poster_path,omitempty"`
+	ReleaseDate       string    `json:"release_date,omitempty"`
+	Character         string    `json:"character,omitempty"`
+	Job               string    `json:"job,omitempty"`
+	DetectedAt        time.Time `json:"detected_at"`
+}
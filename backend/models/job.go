@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Job run triggers
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerStartup  = "startup"
+	JobTriggerManual   = "manual"
+)
+
+// Job run statuses
+const (
+	JobStatusSuccess = "success"
+	JobStatusFailed  = "failed"
+	JobStatusSkipped = "skipped"
+)
+
+// JobRun records a single run of a background job
+type JobRun struct {
+	Job        string    `json:"job"`
+	Trigger    string    `json:"trigger"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DurationMS int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// JobInfo describes a registered background job
+type JobInfo struct {
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule"`
+	Exclusive bool       `json:"exclusive"`
+	Running   bool       `json:"running"`
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	LastRun   *JobRun    `json:"last_run,omitempty"`
+}
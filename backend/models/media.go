@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Media types
+const (
+	MediaTypeMovie = "movie"
+	MediaTypeTV    = "tv"
+)
+
+// Media represents a movie or TV show. The core fields are shared by both
+// kinds; MediaType tells them apart and exactly one of the type-specific
+// extensions is set on details responses.
+//
+// TV shows use Title for the show name and ReleaseDate for the first air date
+// so cards render the same for both kinds. They are also encoded under the
+// TMDB names (name, original_name, first_air_date) for existing clients.
+type Media struct {
+	ID                  int                 `json:"id"`
+	Title               string              `json:"title"`
+	OriginalTitle       string              `json:"original_title"`
+	OriginalLanguage    string              `json:"original_language"`
+	Overview            string              `json:"overview"`
+	PosterPath          string              `json:"poster_path"`
+	BackdropPath        string              `json:"backdrop_path"`
+	ReleaseDate         string              `json:"release_date"`
+	Runtime             int                 `json:"runtime"` // typical episode length for TV shows
+	Status              string              `json:"status"`
+	Tagline             string              `json:"tagline"`
+	VoteAverage         float64             `json:"vote_average"`
+	VoteCount           int                 `json:"vote_count"`
+	Popularity          float64             `json:"popularity"`
+	Adult               bool                `json:"adult"`
+	GenreIDs            []int               `json:"genre_ids"`
+	Genres              []Genre             `json:"genres"`
+	ProductionCompanies []ProductionCompany `json:"production_companies"`
+	SpokenLanguages     []SpokenLanguage    `json:"spoken_languages"`
+	Credits             Credits             `json:"credits"`
+	Ratings             Ratings             `json:"ratings"`
+	MediaType           string              `json:"media_type"` // "movie" or "tv"
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+	TrailerKey          string              `json:"trailerKey,omitempty"`
+	Keywords            []Keyword           `json:"keywords,omitempty"`
+
+	*MovieDetails
+	*TVDetails
+}
+
+// MovieDetails holds the fields only movies have
+type MovieDetails struct {
+	Video               bool        `json:"video"`
+	BelongsToCollection *Collection `json:"belongs_to_collection,omitempty"`
+}
+
+// TVDetails holds the fields only TV shows have
+type TVDetails struct {
+	LastAirDate      string          `json:"last_air_date"`
+	NumberOfSeasons  int             `json:"number_of_seasons"`
+	NumberOfEpisodes int             `json:"number_of_episodes"`
+	OriginCountry    []string        `json:"origin_country"`
+	Type             string          `json:"type"`
+	InProduction     bool            `json:"in_production"`
+	EpisodeRunTime   []int           `json:"episode_run_time"`
+	Networks         []Network       `json:"networks"`
+	CreatedBy        []Creator       `json:"created_by"`
+	Seasons          []SeasonSummary `json:"seasons"`
+	LastEpisodeToAir *Episode        `json:"last_episode_to_air,omitempty"`
+	NextEpisodeToAir *Episode        `json:"next_episode_to_air,omitempty"`
+}
+
+// IsTV reports whether the media is a TV show
+func (m Media) IsTV() bool {
+	return m.MediaType == MediaTypeTV
+}
+
+// Collection returns the collection a movie belongs to, if any
+func (m Media) Collection() *Collection {
+	if m.MovieDetails == nil {
+		return nil
+	}
+	return m.BelongsToCollection
+}
+
+// MarshalJSON adds the TMDB TV field names to TV shows
+func (m Media) MarshalJSON() ([]byte, error) {
+	type media Media
+	if !m.IsTV() {
+		return json.Marshal(media(m))
+	}
+
+	return json.Marshal(struct {
+		media
+		Name         string `json:"name"`
+		OriginalName string `json:"original_name"`
+		FirstAirDate string `json:"first_air_date"`
+	}{
+		media:        media(m),
+		Name:         m.Title,
+		OriginalName: m.OriginalTitle,
+		FirstAirDate: m.ReleaseDate,
+	})
+}
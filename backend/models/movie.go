@@ -1,37 +1,20 @@
 package models
 
-import "time"
-
-// Movie represents a movie with comprehensive information
-type Movie struct {
-	ID                  int                 `json:"id"`
-	Title               string              `json:"title"`
-	OriginalTitle       string              `json:"original_title"`
-	Overview            string              `json:"overview"`
-	PosterPath          string              `json:"poster_path"`
-	BackdropPath        string              `json:"backdrop_path"`
-	ReleaseDate         string              `json:"release_date"`
-	Runtime             int                 `json:"runtime"`
-	Status              string              `json:"status"`
-	Tagline             string              `json:"tagline"`
-	VoteAverage         float64             `json:"vote_average"`
-	VoteCount           int                 `json:"vote_count"`
-	Popularity          float64             `json:"popularity"`
-	Adult               bool                `json:"adult"`
-	Video               bool                `json:"video"`
-	GenreIDs            []int               `json:"genre_ids"`
-	Genres              []Genre             `json:"genres"`
-	ProductionCompanies []ProductionCompany `json:"production_companies"`
-	SpokenLanguages     []SpokenLanguage    `json:"spoken_languages"`
-	Credits             Credits             `json:"credits"`
-	Ratings             Ratings             `json:"ratings"`
-	MediaType           string              `json:"media_type"`
-	CreatedAt           time.Time           `json:"created_at"`
-	UpdatedAt           time.Time           `json:"updated_at"`
-	TrailerKey          string              `json:"trailerKey,omitempty"`
-}
-
-// Genre represents a movie genre
+// Keyword represents a TMDB keyword tag
+type Keyword struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Collection represents a movie collection such as a franchise
+type Collection struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+}
+
+// Genre represents a movie or TV genre
 type Genre struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -59,20 +42,22 @@ type Credits struct {
 
 // CastMember represents a cast member
 type CastMember struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Character   string `json:"character"`
-	ProfilePath string `json:"profile_path"`
-	Order       int    `json:"order"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Character    string `json:"character"`
+	ProfilePath  string `json:"profile_path"`
+	Order        int    `json:"order"`
+	EpisodeCount int    `json:"episode_count,omitempty"`
 }
 
 // CrewMember represents a crew member
 type CrewMember struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Job         string `json:"job"`
-	Department  string `json:"department"`
-	ProfilePath string `json:"profile_path"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Job          string `json:"job"`
+	Department   string `json:"department"`
+	ProfilePath  string `json:"profile_path"`
+	EpisodeCount int    `json:"episode_count,omitempty"`
 }
 
 // Ratings represents ratings from different sources
@@ -84,17 +69,31 @@ type Ratings struct {
 	Metacritic     float64 `json:"metacritic"`
 }
 
-// MovieSearchResult represents a movie search result
+// MovieSearchResult represents a page of movie and/or TV show results
 type MovieSearchResult struct {
-	Page         int     `json:"page"`
-	Results      []Movie `json:"results"`
-	TotalPages   int     `json:"total_pages"`
-	TotalResults int     `json:"total_results"`
+	Page         int        `json:"page"`
+	Results      []Media    `json:"results"`
+	TotalPages   int        `json:"total_pages"`
+	TotalResults int        `json:"total_results"`
+	Dates        *DateRange `json:"dates,omitempty"`
+}
+
+// DateRange is the release window covered by the now playing and upcoming lists
+type DateRange struct {
+	Minimum string `json:"minimum"`
+	Maximum string `json:"maximum"`
+}
+
+// SearchHit represents a result from the local full-text index
+type SearchHit struct {
+	Media      Media             `json:"media"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 // MovieRecommendation represents a movie recommendation
 type MovieRecommendation struct {
-	Movie      Movie   `json:"movie"`
+	Movie      Media   `json:"movie"`
 	Score      float64 `json:"score"`
 	Reason     string  `json:"reason"`
 	Similarity float64 `json:"similarity"`
@@ -113,7 +112,7 @@ type MovieFilter struct {
 
 // MovieList represents a list of movies with pagination
 type MovieList struct {
-	Movies       []Movie `json:"movies"`
+	Movies       []Media `json:"movies"`
 	Page         int     `json:"page"`
 	TotalPages   int     `json:"total_pages"`
 	TotalResults int     `json:"total_results"`
@@ -121,43 +120,10 @@ type MovieList struct {
 	HasPrev      bool    `json:"has_prev"`
 }
 
-// TV represents a TV show with comprehensive information
-// Fields are similar to Movie, but adapted for TV data from TMDB
-// Only fields relevant for search/trending/details are included
-// You can expand as needed for more detail
-
-type TV struct {
-	ID                  int                 `json:"id"`
-	Name                string              `json:"name"`
-	OriginalName        string              `json:"original_name"`
-	Overview            string              `json:"overview"`
-	PosterPath          string              `json:"poster_path"`
-	BackdropPath        string              `json:"backdrop_path"`
-	FirstAirDate        string              `json:"first_air_date"`
-	LastAirDate         string              `json:"last_air_date"`
-	NumberOfSeasons     int                 `json:"number_of_seasons"`
-	NumberOfEpisodes    int                 `json:"number_of_episodes"`
-	Status              string              `json:"status"`
-	Tagline             string              `json:"tagline"`
-	VoteAverage         float64             `json:"vote_average"`
-	VoteCount           int                 `json:"vote_count"`
-	Popularity          float64             `json:"popularity"`
-	GenreIDs            []int               `json:"genre_ids"`
-	Genres              []Genre             `json:"genres"`
-	ProductionCompanies []ProductionCompany `json:"production_companies"`
-	SpokenLanguages     []SpokenLanguage    `json:"spoken_languages"`
-	Credits             Credits             `json:"credits"`
-	Ratings             Ratings             `json:"ratings"`
-	MediaType           string              `json:"media_type"`
-	CreatedAt           time.Time           `json:"created_at"`
-	UpdatedAt           time.Time           `json:"updated_at"`
-	TrailerKey          string              `json:"trailerKey,omitempty"`
-}
-
 // TVSearchResult represents a TV show search result
 type TVSearchResult struct {
-	Page         int  `json:"page"`
-	Results      []TV `json:"results"`
-	TotalPages   int  `json:"total_pages"`
-	TotalResults int  `json:"total_results"`
+	Page         int     `json:"page"`
+	Results      []Media `json:"results"`
+	TotalPages   int     `json:"total_pages"`
+	TotalResults int     `json:"total_results"`
 }
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Notification types
+const (
+	NotificationAvailabilityAdded   = "availability_added"
+	NotificationAvailabilityRemoved = "availability_removed"
+	NotificationPersonAnnounced     = "person_announced"
+	NotificationPersonReleased      = "person_released"
+)
+
+// Notification represents a message in a user's inbox
+type Notification struct {
+	ID           int       `json:"id"`
+	UserID       string    `json:"user_id"`
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	Message      string    `json:"message"`
+	MediaType    string    `json:"media_type,omitempty"`
+	MediaID      int       `json:"media_id,omitempty"`
+	ProviderID   int       `json:"provider_id,omitempty"`
+	ProviderName string    `json:"provider_name,omitempty"`
+	Region       string    `json:"region,omitempty"`
+	PersonID     int       `json:"person_id,omitempty"`
+	PersonName   string    `json:"person_name,omitempty"`
+	Read         bool      `json:"read"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NotificationReadRequest represents a request to mark notifications as read.
+// An empty list marks every notification as read.
+type NotificationReadRequest struct {
+	IDs []int `json:"ids"`
+}
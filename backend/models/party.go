@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// Watch party voting methods
+const (
+	VotingBorda        = "borda"
+	VotingRankedChoice = "ranked_choice"
+)
+
+// Watch party statuses
+const (
+	PartyOpen   = "open"
+	PartyClosed = "closed"
+)
+
+// WatchParty is a session in which a group votes on what to watch. Anyone
+// with the invite code can join and vote until the host closes it.
+type WatchParty struct {
+	Code         string             `json:"code"`                 // secret invite code
+	InviteURL    string             `json:"invite_url,omitempty"` // link that shares the code
+	HostID       string             `json:"host_id"`
+	Title        string             `json:"title"`
+	Method       string             `json:"method"` // "borda" or "ranked_choice"
+	Status       string             `json:"status"` // "open" or "closed"
+	Candidates   []PartyCandidate   `json:"candidates"`
+	Participants []PartyParticipant `json:"participants"`
+	Result       PartyResult        `json:"result"` // live until the party closes
+	CreatedAt    time.Time          `json:"created_at"`
+	ExpiresAt    time.Time          `json:"expires_at"`
+	ClosedAt     *time.Time         `json:"closed_at,omitempty"`
+}
+
+// PartyCandidate is a title a watch party can vote for
+type PartyCandidate struct {
+	ID    int   `json:"id"` // position in the party, starting at 1
+	Media Media `json:"media"`
+}
+
+// PartyParticipant is someone who joined a watch party. Ballots are secret;
+// only whether a participant voted is shared.
+type PartyParticipant struct {
+	UserID   string    `json:"user_id"`
+	Voted    bool      `json:"voted"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// PartyResult is the outcome of a watch party's votes so far
+type PartyResult struct {
+	Method    string          `json:"method"`
+	Ballots   int             `json:"ballots"`
+	Winner    *int            `json:"winner"` // candidate ID, nil until someone votes
+	Final     bool            `json:"final"`  // the party is closed
+	Standings []PartyStanding `json:"standings"`
+	Rounds    []PartyRound    `json:"rounds,omitempty"` // instant-runoff rounds for ranked_choice
+}
+
+// PartyStanding is how a candidate placed. Points are Borda points, or the
+// votes in the last round a candidate took part in for ranked_choice.
+type PartyStanding struct {
+	CandidateID  int     `json:"candidate_id"`
+	Points       float64 `json:"points"`
+	FirstChoices int     `json:"first_choices"` // ballots ranking it first
+}
+
+// PartyRound is one round of an instant-runoff count
+type PartyRound struct {
+	Tallies    []PartyTally `json:"tallies"`
+	Exhausted  int          `json:"exhausted"`            // ballots ranking none of the remaining candidates
+	Eliminated *int         `json:"eliminated,omitempty"` // candidate ID, nil in the deciding round
+}
+
+// PartyTally is a candidate's votes in an instant-runoff round
+type PartyTally struct {
+	CandidateID int `json:"candidate_id"`
+	Votes       int `json:"votes"`
+}
+
+// WatchPartyCreateRequest represents a request to start a watch party.
+// Candidates are gathered from every source given.
+type WatchPartyCreateRequest struct {
+	Title         string              `json:"title"`
+	Method        string              `json:"method"` // defaults to "ranked_choice"
+	Candidates    []PartyCandidateRef `json:"candidates"`
+	WatchlistID   int                 `json:"watchlist_id"` // to-watch titles of this watchlist
+	Pick          *PickRequest        `json:"pick"`         // the picker's shortlist for these constraints
+	MaxCandidates int                 `json:"max_candidates"`
+}
+
+// PartyCandidateRef identifies a title to add to a watch party
+type PartyCandidateRef struct {
+	MediaType string `json:"media_type"` // defaults to "movie"
+	MediaID   int    `json:"media_id"`
+}
+
+// PartyVoteRequest represents a participant's ballot
+type PartyVoteRequest struct {
+	Ranking []int `json:"ranking"` // candidate IDs, favorite first; a single ID is a plain vote
+}
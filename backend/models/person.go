@@ -0,0 +1,63 @@
+package models
+
+// Person represents a cast or crew member as returned by TMDB.
+// Search and trending results only fill the summary fields; details also
+// include the biography and images.
+type Person struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	ProfilePath        string         `json:"profile_path"`
+	KnownForDepartment string         `json:"known_for_department"`
+	Popularity         float64        `json:"popularity"`
+	Gender             int            `json:"gender,omitempty"`
+	Biography          string         `json:"biography,omitempty"`
+	Birthday           string         `json:"birthday,omitempty"`
+	Deathday           string         `json:"deathday,omitempty"`
+	PlaceOfBirth       string         `json:"place_of_birth,omitempty"`
+	AlsoKnownAs        []string       `json:"also_known_as,omitempty"`
+	Homepage           string         `json:"homepage,omitempty"`
+	IMDBID             string         `json:"imdb_id,omitempty"`
+	Images             []Image        `json:"images,omitempty"`
+	KnownFor           []PersonCredit `json:"known_for,omitempty"`
+}
+
+// Image represents an image such as a profile photo or episode still
+type Image struct {
+	FilePath    string  `json:"file_path"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	AspectRatio float64 `json:"aspect_ratio"`
+	VoteAverage float64 `json:"vote_average"`
+}
+
+// PersonCredit represents a single movie or TV credit in a filmography
+type PersonCredit struct {
+	ID           int     `json:"id"`
+	MediaType    string  `json:"media_type"`
+	Title        string  `json:"title"`
+	ReleaseDate  string  `json:"release_date"`
+	PosterPath   string  `json:"poster_path"`
+	Character    string  `json:"character,omitempty"`
+	Job          string  `json:"job,omitempty"`
+	Department   string  `json:"department,omitempty"`
+	EpisodeCount int     `json:"episode_count,omitempty"`
+	GenreIDs     []int   `json:"genre_ids"`
+	VoteAverage  float64 `json:"vote_average"`
+	VoteCount    int     `json:"vote_count"`
+	Popularity   float64 `json:"popularity"`
+}
+
+// PersonCredits represents a person's combined movie and TV filmography
+type PersonCredits struct {
+	PersonID int            `json:"person_id"`
+	Cast     []PersonCredit `json:"cast"`
+	Crew     []PersonCredit `json:"crew"`
+}
+
+// PersonSearchResult represents a person search result
+type PersonSearchResult struct {
+	Page         int      `json:"page"`
+	Results      []Person `json:"results"`
+	TotalPages   int      `json:"total_pages"`
+	TotalResults int      `json:"total_results"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// PickRequest describes what a group feels like watching tonight. Every
+// constraint is optional.
+type PickRequest struct {
+	Viewers        []string `json:"viewers"`          // user IDs watching along with the requester; only their public watchlists add titles
+	MaxRuntime     int      `json:"max_runtime"`      // minutes; the typical episode length for TV shows
+	Moods          []string `json:"moods"`            // e.g. "funny" or "scary"; at least one must match
+	AvoidMoods     []string `json:"avoid_moods"`      // moods none of the genres may match
+	IncludeGenres  []int    `json:"include_genres"`   // TMDB genre IDs; at least one must match
+	ExcludeGenres  []int    `json:"exclude_genres"`   // TMDB genre IDs none may match
+	MinRating      float64  `json:"min_rating"`       // TMDB vote average
+	MyServicesOnly bool     `json:"my_services_only"` // only titles streaming on the requester's services
+	WatchlistID    int      `json:"watchlist_id"`     // only titles from this watchlist
+	MediaType      string   `json:"media_type"`       // "movie", "tv" or empty for both
+	Limit          int      `json:"limit"`            // shortlist size
+}
+
+// PickCandidate is a title on the shortlist for tonight
+type PickCandidate struct {
+	Media          Media      `json:"media"`
+	Score          float64    `json:"score"` // between 0 and 1
+	Reasons        []string   `json:"reasons"`
+	WatchlistedBy  []string   `json:"watchlisted_by"`      // viewers who saved it
+	RecommendedFor []string   `json:"recommended_for"`     // viewers it was recommended to
+	Providers      []Provider `json:"providers,omitempty"` // requester's services it streams on
+}
+
+// PickResult is a ranked shortlist and one title drawn from it at random,
+// weighted by score
+type PickResult struct {
+	Pick           *PickCandidate  `json:"pick"` // nil when nothing matched
+	Shortlist      []PickCandidate `json:"shortlist"`
+	Viewers        []string        `json:"viewers"`         // viewers taking part, requester first
+	SkippedViewers []string        `json:"skipped_viewers"` // viewers without a public watchlist
+	Considered     int             `json:"considered"`      // titles checked against the constraints
+}
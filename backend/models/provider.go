@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Provider represents a streaming, rental or purchase service
+type Provider struct {
+	ID              int    `json:"provider_id"`
+	Name            string `json:"provider_name"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+// RegionProviders lists where a title can be watched in one country
+type RegionProviders struct {
+	Region   string     `json:"region"`
+	Link     string     `json:"link"`
+	Flatrate []Provider `json:"flatrate"`
+	Rent     []Provider `json:"rent"`
+	Buy      []Provider `json:"buy"`
+	Free     []Provider `json:"free,omitempty"`
+	Ads      []Provider `json:"ads,omitempty"`
+}
+
+// WatchProviders represents the availability of a movie or TV show per country
+type WatchProviders struct {
+	ID        int                        `json:"id"`
+	MediaType string                     `json:"media_type"`
+	Results   map[string]RegionProviders `json:"results"` // keyed by ISO 3166-1 country code
+}
+
+// UserSettings represents a user's viewing preferences
+type UserSettings struct {
+	UserID      string `json:"user_id"`
+	Region      string `json:"region"`       // ISO 3166-1 country code
+	ProviderIDs []int  `json:"provider_ids"` // subscribed streaming services
+	// FollowedPeople holds TMDB person IDs whose upcoming work appears in the
+	// user's release calendar and whose new projects trigger alerts
+	FollowedPeople []int     `json:"followed_people"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// UserSettingsUpdateRequest represents a request to update user settings.
+// Omitted fields are left unchanged.
+type UserSettingsUpdateRequest struct {
+	Region      *string `json:"region"`
+	ProviderIDs []int   `json:"provider_ids"`
+}
+
+// DiscoverFilter represents filters for discovering movies and TV shows
+type DiscoverFilter struct {
+	MediaType   string `json:"media_type"`
+	GenreIDs    []int  `json:"genre_ids"`
+	SortBy      string `json:"sort_by"`
+	Region      string `json:"region"`
+	ProviderIDs []int  `json:"provider_ids"`
+	Page        int    `json:"page"`
+}
@@ -77,14 +77,29 @@ type TrendingResponse struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// MediaListResponse represents a page of a curated list such as upcoming or
+// top rated
+type MediaListResponse struct {
+	Success   bool        `json:"success"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data"`
+	List      string      `json:"list"`
+	MediaType string      `json:"media_type"`
+	Region    string      `json:"region,omitempty"`
+	Dates     *DateRange  `json:"dates,omitempty"`
+	Meta      Meta        `json:"meta"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
 // RecommendationResponse represents a recommendation response
 type RecommendationResponse struct {
-	Success         bool        `json:"success"`
-	Message         string      `json:"message"`
-	Recommendations interface{} `json:"recommendations"`
-	UserID          string      `json:"user_id"`
-	Meta            Meta        `json:"meta"`
-	Timestamp       time.Time   `json:"timestamp"`
+	Success         bool                  `json:"success"`
+	Message         string                `json:"message"`
+	Recommendations interface{}           `json:"recommendations"`
+	UserID          string                `json:"user_id"`
+	Experiment      *ExperimentAssignment `json:"experiment,omitempty"` // A/B test variant that served them
+	Meta            Meta                  `json:"meta"`
+	Timestamp       time.Time             `json:"timestamp"`
 }
 
 // Helper functions for creating responses
@@ -139,12 +154,27 @@ func NewTrendingResponse(trending interface{}, timeframe string, meta Meta) Tren
 	}
 }
 
-func NewRecommendationResponse(recommendations interface{}, userID string, meta Meta) RecommendationResponse {
+func NewMediaListResponse(result *MovieSearchResult, mediaType string, list string, region string, meta Meta) MediaListResponse {
+	return MediaListResponse{
+		Success:   true,
+		Message:   "List retrieved successfully",
+		Data:      result.Results,
+		List:      list,
+		MediaType: mediaType,
+		Region:    region,
+		Dates:     result.Dates,
+		Meta:      meta,
+		Timestamp: time.Now(),
+	}
+}
+
+func NewRecommendationResponse(recommendations interface{}, userID string, experiment *ExperimentAssignment, meta Meta) RecommendationResponse {
 	return RecommendationResponse{
 		Success:         true,
 		Message:         "Recommendations generated successfully",
 		Recommendations: recommendations,
 		UserID:          userID,
+		Experiment:      experiment,
 		Meta:            meta,
 		Timestamp:       time.Now(),
 	}
@@ -0,0 +1,61 @@
+package models
+
+import "encoding/json"
+
+// SimilarTitle is a movie or TV show similar to another, with what the two
+// have in common
+type SimilarTitle struct {
+	Media
+	Score   float64           `json:"score"` // between 0 and 1
+	Signals SimilaritySignals `json:"signals"`
+	Shared  SharedFeatures    `json:"shared"`
+}
+
+// MarshalJSON keeps the title's own fields at the top level, where clients of
+// the unscored similar lists read them, and adds the similarity after them
+func (t SimilarTitle) MarshalJSON() ([]byte, error) {
+	media, err := json.Marshal(t.Media)
+	if err != nil {
+		return nil, err
+	}
+	similarity, err := json.Marshal(struct {
+		Score   float64           `json:"score"`
+		Signals SimilaritySignals `json:"signals"`
+		Shared  SharedFeatures    `json:"shared"`
+	}{t.Score, t.Signals, t.Shared})
+	if err != nil {
+		return nil, err
+	}
+
+	// Join the two objects
+	joined := append(media[:len(media)-1:len(media)-1], ',')
+	return append(joined, similarity[1:]...), nil
+}
+
+// SimilaritySignals are the similarities, each between 0 and 1, that make up
+// a similar title's score
+type SimilaritySignals struct {
+	Genres     float64 `json:"genres"`
+	Keywords   float64 `json:"keywords"`
+	People     float64 `json:"people"`
+	Collection float64 `json:"collection"`
+	Overview   float64 `json:"overview"` // TF-IDF cosine similarity of the overviews
+	TMDB       float64 `json:"tmdb"`     // how many of TMDB's recommendations and similar lists include it
+}
+
+// SharedFeatures are the traits two titles share
+type SharedFeatures struct {
+	Genres        []Genre        `json:"genres"`
+	Keywords      []Keyword      `json:"keywords"`
+	People        []SharedPerson `json:"people"`
+	Collection    *Collection    `json:"collection,omitempty"`
+	OverviewTerms []string       `json:"overview_terms"` // most distinctive words both overviews use
+	Sources       []string       `json:"sources"`        // TMDB lists it came from: "recommendations", "similar"
+}
+
+// SharedPerson is someone who worked on both titles
+type SharedPerson struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"` // "cast", a crew job such as "Director", or "Creator"
+}
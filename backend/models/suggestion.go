@@ -0,0 +1,21 @@
+package models
+
+// Suggestion types returned by the typeahead endpoint
+const (
+	SuggestionMovie      = "movie"
+	SuggestionTV         = "tv"
+	SuggestionPerson     = "person"
+	SuggestionGenre      = "genre"
+	SuggestionCollection = "collection"
+)
+
+// Suggestion represents a lightweight typeahead suggestion
+type Suggestion struct {
+	Type        string  `json:"type"` // "movie", "tv", "person", "genre", "collection"
+	ID          int     `json:"id"`
+	Label       string  `json:"label"`
+	Subtitle    string  `json:"subtitle,omitempty"`
+	ImagePath   string  `json:"image_path,omitempty"`
+	Score       float64 `json:"score"`
+	InWatchlist bool    `json:"in_watchlist,omitempty"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// TrendingSnapshot records a trending chart at a point in time
+type TrendingSnapshot struct {
+	MediaType  string                  `json:"media_type"`
+	Timeframe  string                  `json:"timeframe"`
+	CapturedAt time.Time               `json:"captured_at"`
+	Entries    []TrendingSnapshotEntry `json:"entries"`
+}
+
+// TrendingSnapshotEntry is a title's position in a trending snapshot
+type TrendingSnapshotEntry struct {
+	Rank       int     `json:"rank"`
+	ID         int     `json:"id"`
+	MediaType  string  `json:"media_type"`
+	Title      string  `json:"title"`
+	PosterPath string  `json:"poster_path"`
+	Popularity float64 `json:"popularity"`
+	GenreIDs   []int   `json:"genre_ids,omitempty"`
+}
+
+// TrendingMover is a title whose rank changed between two snapshots. A zero
+// rank means the title wasn't in that chart.
+type TrendingMover struct {
+	ID           int    `json:"id"`
+	MediaType    string `json:"media_type"`
+	Title        string `json:"title"`
+	PosterPath   string `json:"poster_path"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previous_rank"`
+	Change       int    `json:"change"`
+}
+
+// TrendingMovers compares the latest trending chart with an earlier one
+type TrendingMovers struct {
+	MediaType  string          `json:"media_type"`
+	Timeframe  string          `json:"timeframe"`
+	CapturedAt time.Time       `json:"captured_at"`
+	ComparedTo time.Time       `json:"compared_to"`
+	Rising     []TrendingMover `json:"rising"`
+	Falling    []TrendingMover `json:"falling"`
+	New        []TrendingMover `json:"new"`
+	DroppedOut []TrendingMover `json:"dropped_out"`
+}
+
+// TrendingHistoryPoint is a title's rank in one snapshot
+type TrendingHistoryPoint struct {
+	CapturedAt time.Time `json:"captured_at"`
+	Rank       int       `json:"rank"`
+}
+
+// TrendingHistory describes how a title has moved through a trending chart
+type TrendingHistory struct {
+	ID              int                    `json:"id"`
+	MediaType       string                 `json:"media_type"`
+	Timeframe       string                 `json:"timeframe"`
+	Title           string                 `json:"title"`
+	PosterPath      string                 `json:"poster_path"`
+	CurrentRank     int                    `json:"current_rank"`
+	BestRank        int                    `json:"best_rank"`
+	Appearances     int                    `json:"appearances"`
+	FirstSeen       *time.Time             `json:"first_seen,omitempty"`
+	StreakStartedAt *time.Time             `json:"streak_started_at,omitempty"`
+	StreakHours     float64                `json:"streak_hours"`
+	Points          []TrendingHistoryPoint `json:"points"`
+}
+
+// TrendingGenreFilter selects trending titles by genre
+type TrendingGenreFilter struct {
+	GenreIDs []int
+	// MatchAll requires every genre instead of any of them
+	MatchAll  bool
+	Timeframe string
+	MediaType string // "movie", "tv" or "all"
+	SortBy    string // e.g. "popularity.desc"; empty keeps trending order
+}
+
+// TrendingGenre is a genre's share of the titles currently trending
+type TrendingGenre struct {
+	Genre   Genre                `json:"genre"`
+	Count   int                  `json:"count"`
+	Share   float64              `json:"share"`
+	Popular bool                 `json:"popular"`
+	History []TrendingGenrePoint `json:"history"`
+}
+
+// TrendingGenrePoint is a genre's share of a recorded top 20
+type TrendingGenrePoint struct {
+	CapturedAt time.Time `json:"captured_at"`
+	Share      float64   `json:"share"`
+}
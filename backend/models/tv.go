@@ -0,0 +1,59 @@
+package models
+
+// Network represents a TV network
+type Network struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	LogoPath      string `json:"logo_path"`
+	OriginCountry string `json:"origin_country"`
+}
+
+// Creator represents a creator of a TV show
+type Creator struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ProfilePath string `json:"profile_path"`
+}
+
+// SeasonSummary represents a season as listed on a TV show
+type SeasonSummary struct {
+	ID           int    `json:"id"`
+	SeasonNumber int    `json:"season_number"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	AirDate      string `json:"air_date"`
+	EpisodeCount int    `json:"episode_count"`
+	PosterPath   string `json:"poster_path"`
+}
+
+// Season represents a TV season with its episodes
+type Season struct {
+	ID           int       `json:"id"`
+	ShowID       int       `json:"show_id"`
+	SeasonNumber int       `json:"season_number"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview"`
+	AirDate      string    `json:"air_date"`
+	PosterPath   string    `json:"poster_path"`
+	VoteAverage  float64   `json:"vote_average"`
+	Episodes     []Episode `json:"episodes"`
+}
+
+// Episode represents a single TV episode
+type Episode struct {
+	ID             int          `json:"id"`
+	ShowID         int          `json:"show_id"`
+	SeasonNumber   int          `json:"season_number"`
+	EpisodeNumber  int          `json:"episode_number"`
+	Name           string       `json:"name"`
+	Overview       string       `json:"overview"`
+	AirDate        string       `json:"air_date"`
+	Runtime        int          `json:"runtime"`
+	StillPath      string       `json:"still_path"`
+	VoteAverage    float64      `json:"vote_average"`
+	VoteCount      int          `json:"vote_count"`
+	ProductionCode string       `json:"production_code,omitempty"`
+	GuestStars     []CastMember `json:"guest_stars,omitempty"`
+	Crew           []CrewMember `json:"crew,omitempty"`
+	Stills         []Image      `json:"stills,omitempty"`
+}
@@ -16,15 +16,112 @@ type Watchlist struct {
 
 // WatchlistItem represents an item in a watchlist
 type WatchlistItem struct {
-	ID          int       `json:"id"`
-	WatchlistID int       `json:"watchlist_id"`
-	MovieID     int       `json:"movie_id"`
-	Movie       Movie     `json:"movie"`
-	Status      string    `json:"status"` // "to_watch", "watching", "completed", "dropped"
-	Rating      float64   `json:"rating"`
-	Notes       string    `json:"notes"`
-	AddedAt     time.Time `json:"added_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int            `json:"id"`
+	WatchlistID int            `json:"watchlist_id"`
+	MovieID     int            `json:"movie_id"`
+	MediaType   string         `json:"media_type"` // "movie" or "tv"
+	Media       Media          `json:"movie"`      // encoded as "movie" for existing clients
+	Status      string         `json:"status"`     // "to_watch", "watching", "completed", "dropped"
+	Rating      float64        `json:"rating"`
+	Notes       string         `json:"notes"`
+	Progress    *TVProgress    `json:"progress,omitempty"`
+	Snapshot    *MediaSnapshot `json:"snapshot,omitempty"`
+	AddedAt     time.Time      `json:"added_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// MediaSnapshot is the title metadata stored on a watchlist item so the
+// watchlist can be listed without calling TMDB
+type MediaSnapshot struct {
+	Title       string    `json:"title"`
+	PosterPath  string    `json:"poster_path"`
+	ReleaseDate string    `json:"release_date"`
+	Runtime     int       `json:"runtime"`
+	GenreIDs    []int     `json:"genre_ids"`
+	Genres      []Genre   `json:"genres"`
+	VoteAverage float64   `json:"vote_average"`
+	Popularity  float64   `json:"popularity"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// NewMediaSnapshot captures the listing metadata of a movie or TV show
+func NewMediaSnapshot(media Media) *MediaSnapshot {
+	snapshot := &MediaSnapshot{
+		Title:       media.Title,
+		PosterPath:  media.PosterPath,
+		ReleaseDate: media.ReleaseDate,
+		Runtime:     media.Runtime,
+		GenreIDs:    media.GenreIDs,
+		Genres:      media.Genres,
+		VoteAverage: media.VoteAverage,
+		Popularity:  media.Popularity,
+		RefreshedAt: time.Now(),
+	}
+
+	// Details responses only carry full genres
+	if len(snapshot.GenreIDs) == 0 {
+		snapshot.GenreIDs = make([]int, len(media.Genres))
+		for i, genre := range media.Genres {
+			snapshot.GenreIDs[i] = genre.ID
+		}
+	}
+
+	return snapshot
+}
+
+// Media builds a slim media card from the snapshot
+func (s MediaSnapshot) Media(id int, mediaType string) Media {
+	return Media{
+		ID:          id,
+		Title:       s.Title,
+		PosterPath:  s.PosterPath,
+		ReleaseDate: s.ReleaseDate,
+		Runtime:     s.Runtime,
+		GenreIDs:    s.GenreIDs,
+		Genres:      s.Genres,
+		VoteAverage: s.VoteAverage,
+		Popularity:  s.Popularity,
+		MediaType:   mediaType,
+	}
+}
+
+// TVProgress tracks episode-level watch progress for a TV show item.
+// WatchedEpisodes is the source of truth; the other fields are computed
+// from it and the show's season layout.
+type TVProgress struct {
+	WatchedEpisodes   []WatchedEpisode `json:"watched_episodes"`
+	Seasons           []SeasonProgress `json:"seasons"`
+	NextEpisode       *Episode         `json:"next_episode,omitempty"`
+	WatchedCount      int              `json:"watched_count"`
+	TotalEpisodes     int              `json:"total_episodes"`
+	CompletionPercent float64          `json:"completion_percent"`
+	WatchedMinutes    int              `json:"watched_minutes"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// WatchedEpisode records a single watched episode
+type WatchedEpisode struct {
+	SeasonNumber  int       `json:"season_number"`
+	EpisodeNumber int       `json:"episode_number"`
+	Runtime       int       `json:"runtime"`
+	WatchedAt     time.Time `json:"watched_at"`
+}
+
+// SeasonProgress represents completion of a single season
+type SeasonProgress struct {
+	SeasonNumber      int     `json:"season_number"`
+	Name              string  `json:"name"`
+	EpisodeCount      int     `json:"episode_count"`
+	WatchedCount      int     `json:"watched_count"`
+	CompletionPercent float64 `json:"completion_percent"`
+}
+
+// EpisodeProgressRequest represents a request to mark an episode, season or
+// whole show as watched or unwatched
+type EpisodeProgressRequest struct {
+	SeasonNumber  int   `json:"season_number"`
+	EpisodeNumber int   `json:"episode_number"`
+	Watched       *bool `json:"watched"` // defaults to true
 }
 
 // WatchlistCreateRequest represents a request to create a watchlist
@@ -43,10 +140,11 @@ type WatchlistUpdateRequest struct {
 
 // WatchlistItemAddRequest represents a request to add an item to watchlist
 type WatchlistItemAddRequest struct {
-	MovieID int     `json:"movie_id" validate:"required"`
-	Status  string  `json:"status" validate:"oneof=to_watch watching completed dropped"`
-	Rating  float64 `json:"rating" validate:"min=0,max=10"`
-	Notes   string  `json:"notes"`
+	MovieID   int     `json:"movie_id" validate:"required"`
+	MediaType string  `json:"media_type" validate:"omitempty,oneof=movie tv"` // defaults to "movie"
+	Status    string  `json:"status" validate:"oneof=to_watch watching completed dropped"`
+	Rating    float64 `json:"rating" validate:"min=0,max=10"`
+	Notes     string  `json:"notes"`
 }
 
 // WatchlistItemUpdateRequest represents a request to update a watchlist item
@@ -58,20 +156,84 @@ type WatchlistItemUpdateRequest struct {
 
 // WatchlistStats represents statistics for a watchlist
 type WatchlistStats struct {
-	TotalItems     int     `json:"total_items"`
-	CompletedItems int     `json:"completed_items"`
-	WatchingItems  int     `json:"watching_items"`
-	ToWatchItems   int     `json:"to_watch_items"`
-	DroppedItems   int     `json:"dropped_items"`
-	AverageRating  float64 `json:"average_rating"`
-	TotalHours     int     `json:"total_hours"`
+	TotalItems      int     `json:"total_items"`
+	CompletedItems  int     `json:"completed_items"`
+	WatchingItems   int     `json:"watching_items"`
+	ToWatchItems    int     `json:"to_watch_items"`
+	DroppedItems    int     `json:"dropped_items"`
+	MovieItems      int     `json:"movie_items"`
+	TVItems         int     `json:"tv_items"`
+	AverageRating   float64 `json:"average_rating"`
+	TotalHours      int     `json:"total_hours"`
+	WatchedEpisodes int     `json:"watched_episodes"`
 }
 
 // WatchlistRecommendation represents a recommendation based on watchlist
 type WatchlistRecommendation struct {
-	Movie       Movie   `json:"movie"`
-	Score       float64 `json:"score"`
-	Reason      string  `json:"reason"`
-	GenreMatch  float64 `json:"genre_match"`
-	RatingMatch float64 `json:"rating_match"`
+	Media              Media    `json:"movie"`
+	Score              float64  `json:"score"`
+	Reason             string   `json:"reason"`
+	GenreMatch         float64  `json:"genre_match"`
+	RatingMatch        float64  `json:"rating_match"`
+	KeywordMatch       float64  `json:"keyword_match"`
+	PeopleMatch        float64  `json:"people_match"`         // shared cast, directors and creators
+	CollaborativeMatch float64  `json:"collaborative_match"`  // predicted from users with similar taste
+	BecauseOf          []string `json:"because_of,omitempty"` // liked watchlist titles TMDB relates it to
+	// Redundancy is how much the title overlaps in genre, decade and
+	// language with the results ranked above it, between 0 and 1
+	Redundancy   float64                     `json:"redundancy"`
+	Explanations []RecommendationExplanation `json:"explanations"` // strongest first
+}
+
+// Recommendation explanation types
+const (
+	ExplanationLikedTitle    = "liked_title"
+	ExplanationPerson        = "person"
+	ExplanationGenre         = "genre"
+	ExplanationKeyword       = "keyword"
+	ExplanationLanguage      = "language"
+	ExplanationDecade        = "decade"
+	ExplanationRuntime       = "runtime"
+	ExplanationRating        = "rating"
+	ExplanationMediaType     = "media_type"
+	ExplanationCollaborative = "collaborative"
+	ExplanationPopularity    = "popularity"
+)
+
+// RecommendationExplanation is one thing that counted toward a
+// recommendation's score. The contributions of a recommendation add up to
+// its score.
+type RecommendationExplanation struct {
+	Type         string  `json:"type"`
+	Text         string  `json:"text"`
+	Feature      string  `json:"feature,omitempty"` // taste profile trait such as "director:137427"
+	Affinity     float64 `json:"affinity"`          // how much the user likes it, between -1 and 1
+	Weight       float64 `json:"weight"`            // how much it counts toward the score
+	Contribution float64 `json:"contribution"`      // affinity times weight
+	MediaType    string  `json:"media_type,omitempty"`
+	MediaID      int     `json:"media_id,omitempty"`  // liked title, for liked_title explanations
+	PersonID     int     `json:"person_id,omitempty"` // for person explanations
+}
+
+// Recommendation feedback types
+const (
+	FeedbackNotInterested = "not_interested"
+	FeedbackAlreadySeen   = "already_seen"
+	FeedbackMoreLikeThis  = "more_like_this"
+)
+
+// RecommendationFeedback records what a user thought of a recommended title
+type RecommendationFeedback struct {
+	MediaType string    `json:"media_type"`
+	MediaID   int       `json:"media_id"`
+	Title     string    `json:"title"`
+	Feedback  string    `json:"feedback"` // "not_interested", "already_seen" or "more_like_this"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RecommendationFeedbackRequest represents feedback on a recommended title
+type RecommendationFeedbackRequest struct {
+	MediaType string `json:"media_type" validate:"omitempty,oneof=movie tv"` // defaults to "movie"
+	MediaID   int    `json:"media_id" validate:"required"`
+	Feedback  string `json:"feedback" validate:"oneof=not_interested already_seen more_like_this"`
 }
@@ -13,6 +13,18 @@ func SetupRoutes(
 	movieController *controllers.MovieController,
 	watchlistController *controllers.WatchlistController,
 	trendingController *controllers.TrendingController,
+	suggestController *controllers.SuggestController,
+	peopleController *controllers.PeopleController,
+	tvController *controllers.TVController,
+	providerController *controllers.ProviderController,
+	discoverController *controllers.DiscoverController,
+	settingsController *controllers.SettingsController,
+	notificationController *controllers.NotificationController,
+	adminController *controllers.AdminController,
+	listController *controllers.ListController,
+	calendarController *controllers.CalendarController,
+	pickController *controllers.PickController,
+	partyController *controllers.PartyController,
 	logger *middleware.Logger,
 ) *mux.Router {
 	router := mux.NewRouter()
@@ -39,8 +51,10 @@ func SetupRoutes(
 	movieRoutes.HandleFunc("/search", movieController.SearchMovies).Methods("GET")
 	movieRoutes.HandleFunc("/{id:[0-9]+}", movieController.GetMovieDetails).Methods("GET")
 	movieRoutes.HandleFunc("/{id:[0-9]+}/similar", movieController.GetSimilarMovies).Methods("GET")
+	movieRoutes.HandleFunc("/{id:[0-9]+}/providers", providerController.GetMovieProviders).Methods("GET")
 	movieRoutes.HandleFunc("/genres", movieController.GetGenres).Methods("GET")
 	movieRoutes.HandleFunc("/genres/{genreId:[0-9]+}", movieController.GetMoviesByGenre).Methods("GET")
+	movieRoutes.HandleFunc("/{list:upcoming|now_playing|popular|top_rated}", listController.GetMovieList).Methods("GET")
 
 	// Trending routes
 	trendingRoutes := api.PathPrefix("/trending").Subrouter()
@@ -48,6 +62,62 @@ func SetupRoutes(
 	trendingRoutes.HandleFunc("/by-genre", trendingController.GetTrendingByGenre).Methods("GET")
 	trendingRoutes.HandleFunc("/stats", trendingController.GetTrendingStats).Methods("GET")
 	trendingRoutes.HandleFunc("/genres", trendingController.GetTrendingGenres).Methods("GET")
+	trendingRoutes.HandleFunc("/snapshots", trendingController.GetTrendingSnapshots).Methods("GET")
+	trendingRoutes.HandleFunc("/movers", trendingController.GetTrendingMovers).Methods("GET")
+	trendingRoutes.HandleFunc("/history/{type}/{id:[0-9]+}", trendingController.GetTitleTrendingHistory).Methods("GET")
+
+	// TV routes
+	tvRoutes := api.PathPrefix("/tv").Subrouter()
+	tvRoutes.HandleFunc("/genres", tvController.GetTVGenres).Methods("GET")
+	tvRoutes.HandleFunc("/{list:airing_today|on_the_air|popular|top_rated}", listController.GetTVList).Methods("GET")
+	tvRoutes.HandleFunc("/{id:[0-9]+}", tvController.GetTVDetails).Methods("GET")
+	tvRoutes.HandleFunc("/{id:[0-9]+}/providers", providerController.GetTVProviders).Methods("GET")
+	tvRoutes.HandleFunc("/{id:[0-9]+}/similar", tvController.GetSimilarTVShows).Methods("GET")
+	tvRoutes.HandleFunc("/{id:[0-9]+}/season/{season:[0-9]+}", tvController.GetTVSeason).Methods("GET")
+	tvRoutes.HandleFunc("/{id:[0-9]+}/season/{season:[0-9]+}/episode/{episode:[0-9]+}", tvController.GetTVEpisode).Methods("GET")
+
+	// People routes
+	peopleRoutes := api.PathPrefix("/people").Subrouter()
+	peopleRoutes.HandleFunc("/search", peopleController.SearchPeople).Methods("GET")
+	peopleRoutes.HandleFunc("/following/feed", peopleController.GetFollowingFeed).Methods("GET")
+	peopleRoutes.HandleFunc("/{id:[0-9]+}", peopleController.GetPersonDetails).Methods("GET")
+	peopleRoutes.HandleFunc("/{id:[0-9]+}/credits", peopleController.GetPersonCredits).Methods("GET")
+	peopleRoutes.HandleFunc("/{id:[0-9]+}/follow", peopleController.FollowPerson).Methods("PUT")
+	peopleRoutes.HandleFunc("/{id:[0-9]+}/follow", peopleController.UnfollowPerson).Methods("DELETE")
+
+	// Provider and discover routes
+	api.HandleFunc("/providers", providerController.GetProviders).Methods("GET")
+	api.HandleFunc("/discover", discoverController.Discover).Methods("GET")
+
+	// Settings routes
+	api.HandleFunc("/settings", settingsController.GetSettings).Methods("GET")
+	api.HandleFunc("/settings", settingsController.UpdateSettings).Methods("PUT")
+
+	// Notification routes
+	api.HandleFunc("/notifications", notificationController.GetNotifications).Methods("GET")
+	api.HandleFunc("/notifications/read", notificationController.MarkNotificationsRead).Methods("POST")
+
+	// Calendar routes
+	api.HandleFunc("/calendar", calendarController.GetCalendar).Methods("GET")
+	api.HandleFunc("/calendar/feed", calendarController.GetCalendarFeed).Methods("GET")
+	api.HandleFunc("/calendar/feed", calendarController.RotateCalendarFeed).Methods("POST")
+	api.HandleFunc("/calendar/{token:[0-9a-f]+}.ics", calendarController.GetCalendarICS).Methods("GET")
+
+	// Pick routes
+	api.HandleFunc("/pick", pickController.Pick).Methods("POST")
+	api.HandleFunc("/pick/moods", pickController.GetMoods).Methods("GET")
+
+	// Watch party routes
+	partyRoutes := api.PathPrefix("/parties").Subrouter()
+	partyRoutes.HandleFunc("", partyController.CreateParty).Methods("POST")
+	partyRoutes.HandleFunc("/{code:[0-9a-f]+}", partyController.GetParty).Methods("GET")
+	partyRoutes.HandleFunc("/{code:[0-9a-f]+}/join", partyController.JoinParty).Methods("POST")
+	partyRoutes.HandleFunc("/{code:[0-9a-f]+}/vote", partyController.Vote).Methods("POST")
+	partyRoutes.HandleFunc("/{code:[0-9a-f]+}/close", partyController.CloseParty).Methods("POST")
+	partyRoutes.HandleFunc("/{code:[0-9a-f]+}/events", partyController.StreamParty).Methods("GET")
+
+	// Typeahead route
+	api.HandleFunc("/suggest", suggestController.GetSuggestions).Methods("GET")
 
 	// Media details route (unified for movie and tv)
 	api.HandleFunc("/media/{type}/{id:[0-9]+}", movieController.GetMediaDetails).Methods("GET")
@@ -59,8 +129,23 @@ func SetupRoutes(
 	watchlistRoutes.HandleFunc("/items", watchlistController.AddToWatchlist).Methods("POST")
 	watchlistRoutes.HandleFunc("/items", watchlistController.UpdateWatchlistItem).Methods("PUT")
 	watchlistRoutes.HandleFunc("/items", watchlistController.RemoveFromWatchlist).Methods("DELETE")
+	watchlistRoutes.HandleFunc("/items/progress", watchlistController.GetItemProgress).Methods("GET")
+	watchlistRoutes.HandleFunc("/items/progress/episode", watchlistController.MarkEpisodeWatched).Methods("POST")
+	watchlistRoutes.HandleFunc("/items/progress/season", watchlistController.MarkSeasonWatched).Methods("POST")
+	watchlistRoutes.HandleFunc("/items/progress/show", watchlistController.MarkShowWatched).Methods("POST")
 	watchlistRoutes.HandleFunc("/stats", watchlistController.GetWatchlistStats).Methods("GET")
 	watchlistRoutes.HandleFunc("/recommendations", watchlistController.GetRecommendations).Methods("GET")
+	watchlistRoutes.HandleFunc("/recommendations/feedback", watchlistController.GetRecommendationFeedback).Methods("GET")
+	watchlistRoutes.HandleFunc("/recommendations/feedback", watchlistController.AddRecommendationFeedback).Methods("POST")
+	watchlistRoutes.HandleFunc("/recommendations/feedback", watchlistController.RemoveRecommendationFeedback).Methods("DELETE")
+
+	// Admin routes
+	adminRoutes := api.PathPrefix("/admin").Subrouter()
+	adminRoutes.HandleFunc("/jobs", adminController.GetJobs).Methods("GET")
+	adminRoutes.HandleFunc("/jobs/{name}/history", adminController.GetJobHistory).Methods("GET")
+	adminRoutes.HandleFunc("/jobs/{name}/run", adminController.RunJob).Methods("POST")
+	adminRoutes.HandleFunc("/watchlists/export", adminController.ExportWatchlists).Methods("GET")
+	adminRoutes.HandleFunc("/experiments", adminController.GetExperimentReport).Methods("GET")
 
 	// 404 handler
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -98,12 +183,14 @@ GET /health
 ### Movies
 
 #### Search Movies
-GET /movies/search?q={query}&page={page}&include_adult={boolean}
+GET /movies/search?q={query}&page={page}&include_adult={boolean}&source={source}
 - Search for movies by title
 - Parameters:
   - q (required): Search query
   - page (optional): Page number (default: 1)
   - include_adult (optional): Include adult content (default: false)
+  - source (optional): "tmdb" (default) or "local" to search the local catalog index
+    with BM25 ranking, "quoted phrases" and highlighted matches
 
 #### Get Movie Details
 GET /movies/{id}
@@ -113,11 +200,20 @@ GET /movies/{id}
 
 #### Get Similar Movies
 GET /movies/{id}/similar?limit={limit}
-- Get movies similar to the specified movie
+- Get movies similar to the specified movie: TMDB recommendations and similar titles ranked by shared genres, keywords, cast and crew, collection and TF-IDF similarity of overviews
+- Each result has the movie's usual fields plus a score between 0 and 1, the per-signal similarities and the shared genres, keywords, people, collection and overview terms
 - Parameters:
   - id (required): TMDB movie ID
   - limit (optional): Number of results (default: 10)
 
+#### Get Movie Watch Providers
+GET /movies/{id}/providers?region={region}
+- Get the services a movie can be streamed (flatrate), rented or bought on, per country
+- Headers: X-User-ID (optional, the user's saved region is used when region is omitted)
+- Parameters:
+  - id (required): TMDB movie ID
+  - region (optional): ISO 3166-1 country code, or "all" for every country (default: all)
+
 #### Get Genres
 GET /movies/genres
 - Get all available movie genres
@@ -130,6 +226,258 @@ GET /movies/genres/{genreId}?page={page}&sort_by={sort_by}
   - page (optional): Page number (default: 1)
   - sort_by (optional): Sort order (default: popularity.desc)
 
+#### Get Movie Lists
+GET /movies/{list}?region={region}&page={page}
+- Get upcoming, now_playing, popular or top_rated movies
+- Upcoming and now playing follow release dates in the region and include the "dates" window they cover
+- Headers: X-User-ID (optional, the user's saved region is used when region is omitted)
+- Parameters:
+  - list (required): upcoming, now_playing, popular or top_rated
+  - region (optional): ISO 3166-1 country code (default: the user's region, then TMDB_REGION)
+  - page (optional): Page number (default: 1)
+
+### TV Shows
+
+#### Get TV Show Details
+GET /tv/{id}
+- Get TV show details including aggregate credits, trailer, networks, creators and seasons
+- Parameters:
+  - id (required): TMDB TV show ID
+
+#### Get TV Season
+GET /tv/{id}/season/{n}
+- Get a season with its episodes, air dates, runtimes and guest stars
+- Parameters:
+  - id (required): TMDB TV show ID
+  - n (required): Season number
+
+#### Get TV Episode
+GET /tv/{id}/season/{n}/episode/{e}
+- Get a single episode with guest stars, crew and stills
+- Parameters:
+  - id (required): TMDB TV show ID
+  - n (required): Season number
+  - e (required): Episode number
+
+#### Get TV Watch Providers
+GET /tv/{id}/providers?region={region}
+- Get the services a TV show can be streamed (flatrate), rented or bought on, per country
+- Headers: X-User-ID (optional, the user's saved region is used when region is omitted)
+- Parameters:
+  - id (required): TMDB TV show ID
+  - region (optional): ISO 3166-1 country code, or "all" for every country (default: all)
+
+#### Get Similar TV Shows
+GET /tv/{id}/similar?limit={limit}
+- Get TV shows similar to the specified show, ranked and explained like similar movies; shared creators count as shared people
+
+#### Get TV Genres
+GET /tv/genres
+- Get all available TV genres
+
+#### Get TV Lists
+GET /tv/{list}?page={page}
+- Get airing_today, on_the_air, popular or top_rated TV shows
+- Parameters:
+  - list (required): airing_today, on_the_air, popular or top_rated
+  - page (optional): Page number (default: 1)
+
+### Discover
+
+#### Get Providers
+GET /providers?media_type={type}&region={region}
+- List the watch providers available in a region, for choosing subscriptions
+- Headers: X-User-ID (optional)
+- Parameters:
+  - media_type (optional): movie or tv (default: movie)
+  - region (optional): ISO 3166-1 country code (default: the user's region)
+
+#### Discover
+GET /discover?media_type={type}&genres={ids}&providers={ids}&only_my_services={bool}&region={region}&sort_by={sort_by}&page={page}
+- Discover movies or TV shows, optionally only those on given streaming services
+- Headers: X-User-ID (required when only_my_services=true)
+- Parameters:
+  - media_type (optional): movie or tv (default: movie)
+  - genres (optional): Comma-separated genre IDs
+  - providers (optional): Comma-separated provider IDs; titles on any of them match
+  - only_my_services (optional): Use the provider subscriptions saved in settings
+  - region (optional): ISO 3166-1 country code (default: the user's region)
+  - sort_by (optional): Sort order (default: popularity.desc)
+  - page (optional): Page number (default: 1)
+
+### Settings
+
+#### Get Settings
+GET /settings
+- Get the user's region and streaming subscriptions
+- Headers: X-User-ID (required)
+
+#### Update Settings
+PUT /settings
+- Update the user's region and/or streaming subscriptions
+- Headers: X-User-ID (required)
+- Body: {"region": "string", "provider_ids": [number]}
+
+### People
+
+#### Search People
+GET /people/search?q={query}&page={page}
+- Search for actors, directors and other crew by name
+- Parameters:
+  - q (required): Search query
+  - page (optional): Page number (default: 1)
+
+#### Get Person Details
+GET /people/{id}
+- Get biography, birth/death dates, known-for department and profile images
+- Parameters:
+  - id (required): TMDB person ID
+
+#### Get Person Credits
+GET /people/{id}/credits?sort_by={sort_by}&type={type}
+- Get a person's combined movie and TV filmography
+- Parameters:
+  - id (required): TMDB person ID
+  - sort_by (optional): "date" or "popularity" (default: "date")
+  - type (optional): "movie", "tv" or "all" (default: "all")
+
+#### Follow Person
+PUT /people/{id}/follow
+- Follow a person so their upcoming movies and show premieres appear in the release calendar and their new projects trigger notifications
+- Headers: X-User-ID (required)
+
+#### Unfollow Person
+DELETE /people/{id}/follow
+- Stop following a person
+- Headers: X-User-ID (required)
+
+#### Get Following Feed
+GET /people/following/feed?page={page}&per_page={per_page}
+- Get newly announced and newly released projects from people the user follows, newest first
+- Followers also get a notification for each new project; people are checked on FOLLOW_CHECK_SCHEDULE
+- Headers: X-User-ID (required)
+- Parameters:
+  - page (optional): Page number (default: 1)
+  - per_page (optional): Results per page, up to 100 (default: 20)
+
+### Calendar
+
+#### Get Calendar
+GET /calendar?days={days}
+- Get upcoming theatrical, digital and physical release dates in the user's region and upcoming episode air dates for watchlisted titles and followed people
+- Headers: X-User-ID (required)
+- Parameters:
+  - days (optional): Days ahead to include, up to 365 (default: 90)
+
+#### Get Calendar Feed
+GET /calendar/feed
+- Get the user's secret iCalendar feed URL to subscribe to from a calendar app
+- Headers: X-User-ID (required)
+
+#### Rotate Calendar Feed
+POST /calendar/feed
+- Replace the feed URL; the old one stops working
+- Headers: X-User-ID (required)
+
+#### Get iCalendar Feed
+GET /calendar/{token}.ics?days={days}
+- Get the calendar as an iCalendar file; the token identifies the user, so no headers are needed
+
+### Pick
+
+#### Pick Something to Watch Tonight
+POST /pick
+- Get a ranked shortlist of titles that fit the constraints and one title drawn from it at random, weighted by score
+- Candidates are the to-watch and watching titles on the viewers' watchlists and the requester's recommendations
+- Other viewers only take part through their public watchlists; those without one are listed in skipped_viewers, though titles they have completed or dropped are still left out
+- Titles any viewer has completed or dropped, or the requester marked not_interested or already_seen, are never picked
+- Titles score by the viewers' average interest and the interest of the least keen viewer, plus their TMDB rating; each comes with reasons and the viewers who saved it or had it recommended
+- Headers: X-User-ID (required)
+- Body: {"viewers": [string], "max_runtime": number, "moods": [string], "avoid_moods": [string], "include_genres": [number], "exclude_genres": [number], "min_rating": number, "my_services_only": boolean, "watchlist_id": number, "media_type": string, "limit": number}
+  - viewers (optional): User IDs watching along with the requester, up to 10 viewers in total
+  - max_runtime (optional): Longest runtime in minutes, the typical episode length for TV shows
+  - moods, avoid_moods (optional): Moods to match at least one of or avoid, see GET /pick/moods
+  - include_genres, exclude_genres (optional): TMDB genre IDs to match at least one of or avoid
+  - min_rating (optional): Lowest TMDB vote average, between 0 and 10
+  - my_services_only (optional): Only titles streaming on the requester's saved services in their region
+  - watchlist_id (optional): Only pick from this watchlist; it must be the requester's or public
+  - media_type (optional): "movie" or "tv" (default: both)
+  - limit (optional): Shortlist size, up to 50 (default: 10)
+
+#### Get Pick Moods
+GET /pick/moods
+- Get the moods a pick can ask for or avoid
+
+### Watch Parties
+
+#### Create Watch Party
+POST /parties
+- Start a session in which a group votes on what to watch; the host joins automatically
+- Candidates come from the listed titles, the to-watch titles of a watchlist and the picker's shortlist, in that order
+- The response includes the secret invite code and an invite_url to share with participants
+- Parties expire 24 hours after they're created
+- Headers: X-User-ID (required)
+- Body: {"title": string, "method": string, "candidates": [{"media_type": string, "media_id": number}], "watchlist_id": number, "pick": object, "max_candidates": number}
+  - method (optional): "ranked_choice" (instant runoff, default) or "borda"
+  - watchlist_id (optional): A watchlist the host owns or that is public
+  - pick (optional): A POST /pick body whose shortlist becomes the candidates; other viewers only add their public watchlists
+  - max_candidates (optional): Up to 20 (default: 10); at least two candidates are needed
+
+#### Get Watch Party
+GET /parties/{code}
+- Get the candidates, participants, who has voted and the current result
+- Ballots are secret; results include standings and, for ranked_choice, every instant-runoff round
+
+#### Join Watch Party
+POST /parties/{code}/join
+- Join an open party through its invite link
+- Headers: X-User-ID (required)
+
+#### Vote
+POST /parties/{code}/vote
+- Rank the candidates, replacing any earlier ballot; voting also joins the party
+- Headers: X-User-ID (required)
+- Body: {"ranking": [number]} (candidate IDs, favorite first; list one ID for a plain vote)
+- Borda gives a candidate one point per candidate it is ranked above; unranked candidates get none
+
+#### Close Watch Party
+POST /parties/{code}/close
+- End voting and make the result final; only the host can close a party
+- Headers: X-User-ID (required)
+
+#### Stream Watch Party
+GET /parties/{code}/events
+- Server-sent events: a "party" event with the full state on connect and after every join, vote and close
+- Changes made through any server instance reach the stream within a couple of seconds
+- The stream ends once the party closes or expires; no headers are needed, so it works with EventSource
+
+### Notifications
+
+#### Get Notifications
+GET /notifications?unread={bool}
+- Get the user's inbox, newest first, with the unread count
+- Includes alerts when a watchlisted title arrives on or leaves one of the user's subscribed services in their region, and when a followed person has a new project
+- Headers: X-User-ID (required)
+- Parameters:
+  - unread (optional): Only return unread notifications
+
+#### Mark Notifications Read
+POST /notifications/read
+- Mark notifications as read
+- Headers: X-User-ID (required)
+- Body: {"ids": [number]} (omit or leave empty to mark all as read)
+
+### Suggestions
+
+#### Get Typeahead Suggestions
+GET /suggest?q={query}&limit={limit}&types={types}
+- Get lightweight title, person, genre and collection suggestions from a local index
+- Headers: X-User-ID (optional, boosts titles in the user's watchlist)
+- Parameters:
+  - q (required): Partial query, typos are tolerated
+  - limit (optional): Number of suggestions (default: 8, max: 20)
+  - types (optional): Comma-separated filter of movie, tv, person, genre, collection
+
 ### Trending
 
 #### Get Trending Movies
@@ -140,20 +488,50 @@ GET /trending?timeframe={timeframe}&page={page}
   - page (optional): Page number (default: 1)
 
 #### Get Trending by Genre
-GET /trending/by-genre?genre_id={genreId}&page={page}&sort_by={sort_by}
-- Get trending movies filtered by genre
+GET /trending/by-genre?genres={genreIds}&match={match}&timeframe={timeframe}&type={type}&sort_by={sort_by}&page={page}
+- Get titles from the top 100 trending movies and TV shows that have the given genres, in trending order
 - Parameters:
-  - genre_id (required): Genre ID
+  - genres (required): Comma-separated genre IDs (genre_id is accepted for a single genre)
+  - match (optional): "any" or "all" of the genres (default: "any")
+  - timeframe (optional): "day" or "week" (default: "week")
+  - type (optional): "movie", "tv" or "all" (default: "all")
+  - sort_by (optional): "popularity", "vote_average" or "release_date" with ".asc" or ".desc" (default: trending order)
   - page (optional): Page number (default: 1)
-  - sort_by (optional): Sort order (default: popularity.desc)
 
 #### Get Trending Stats
 GET /trending/stats
-- Get trending statistics
+- Get trending statistics: counts, top 5 titles, today's biggest movie and TV movers and the titles trending the longest
 
 #### Get Trending Genres
-GET /trending/genres
-- Get trending genres with popularity information
+GET /trending/genres?timeframe={timeframe}&type={type}&points={points}
+- Get each genre's count and share of the top 100 trending titles, plus its share of the recorded top 20 over time
+- Parameters:
+  - timeframe (optional): "day" or "week" (default: "week")
+  - type (optional): "movie", "tv" or "all" (default: "all")
+  - points (optional): Number of snapshots in each genre's history (default: 24)
+
+#### Get Trending Snapshots
+GET /trending/snapshots?type={type}&timeframe={timeframe}&limit={limit}
+- Get the top 20 as recorded by the trending snapshot job, newest first
+- Parameters:
+  - type (optional): "movie" or "tv" (default: "movie")
+  - timeframe (optional): "day" or "week" (default: "day")
+  - limit (optional): Number of snapshots (default: 24)
+
+#### Get Trending Movers
+GET /trending/movers?type={type}&timeframe={timeframe}&since={since}
+- Compare the latest top 20 with an earlier snapshot: rank changes, new entries and drop-outs
+- Returns 404 until at least two snapshots have been recorded
+- Parameters:
+  - type (optional): "movie" or "tv" (default: "movie")
+  - timeframe (optional): "day" or "week" (default: "day")
+  - since (optional): "yesterday" or "last_week" (default: "yesterday")
+
+#### Get Title Trending History
+GET /trending/history/{type}/{id}?timeframe={timeframe}
+- Get a title's rank in every snapshot, its best rank and how long it has stayed in the top 20
+- Parameters:
+  - timeframe (optional): "day" or "week" (default: "day")
 
 ### Watchlist
 
@@ -166,13 +544,15 @@ POST /watchlist
 #### Get Watchlist
 GET /watchlist
 - Get user's watchlist
+- Items are rendered from a stored metadata snapshot (title, poster, runtime, genres) refreshed in the background
 - Headers: X-User-ID (required)
 
 #### Add to Watchlist
 POST /watchlist/items
-- Add a movie to the watchlist
+- Add a movie or TV show to the watchlist
 - Headers: X-User-ID (required)
-- Body: {"movie_id": number, "status": "string", "rating": number, "notes": "string"}
+- Body: {"movie_id": number, "media_type": "movie|tv", "status": "string", "rating": number, "notes": "string"}
+- media_type defaults to "movie"; movie_id holds the TMDB ID of either kind
 
 #### Update Watchlist Item
 PUT /watchlist/items?item_id={itemId}
@@ -185,17 +565,89 @@ DELETE /watchlist/items?item_id={itemId}
 - Remove a movie from the watchlist
 - Headers: X-User-ID (required)
 
+#### Get Episode Progress
+GET /watchlist/items/progress?item_id={itemId}
+- Get per-season completion and the next episode to watch for a TV show item (media_type "tv")
+- Headers: X-User-ID (required)
+
+#### Mark Episode Watched
+POST /watchlist/items/progress/episode?item_id={itemId}
+- Mark a single episode as watched or unwatched
+- Headers: X-User-ID (required)
+- Body: {"season_number": number, "episode_number": number, "watched": boolean}
+
+#### Mark Season Watched
+POST /watchlist/items/progress/season?item_id={itemId}
+- Mark every episode of a season as watched or unwatched
+- Headers: X-User-ID (required)
+- Body: {"season_number": number, "watched": boolean}
+
+#### Mark Show Watched
+POST /watchlist/items/progress/show?item_id={itemId}
+- Mark every episode of the show as watched, or clear progress with "watched": false
+- Headers: X-User-ID (required)
+- Body: {"watched": boolean}
+
 #### Get Watchlist Stats
 GET /watchlist/stats
-- Get watchlist statistics
+- Get watchlist statistics, counting watched episode runtime toward total hours
 - Headers: X-User-ID (required)
 
 #### Get Recommendations
 GET /watchlist/recommendations?limit={limit}
-- Get movie recommendations based on watchlist
+- Get movies and TV shows that aren't on the watchlist yet, ranked against a taste profile of genres, keywords, cast, directors, original language, decade and runtime, weighted by the user's ratings and statuses
+- Blended with an item-item collaborative filtering score (cosine similarity of ratings and statuses across users, rebuilt on RECOMMENDATION_MODEL_SCHEDULE); users and titles with too little data fall back to the content-based score
+- Candidates come from TMDB recommendations and similar titles for liked items, discover queries for favorite genres, the popular lists and titles users with similar taste saved
+- People the user follows count as liked
+- While RECOMMENDATION_EXPERIMENT is set, users are bucketed deterministically into one of RECOMMENDATION_VARIANTS and served its strategy; the response's experiment field names the variant
+- Results are re-ranked by maximal marginal relevance so they spread across genres, decades and languages; each result's redundancy is its overlap with the results above it
+- Each result lists its explanations, strongest first: the liked titles, genres, themes and people behind it with their affinity, weight and contribution to the score (contributions add up to the score), e.g. "Because you rated Arrival 9/10" or "Directed by Denis Villeneuve, whom you follow"
 - Headers: X-User-ID (required)
 - Parameters:
-  - limit (optional): Number of recommendations (default: 10)
+  - limit (optional): Number of recommendations, up to 50 (default: 10)
+
+#### Get Recommendation Feedback
+GET /watchlist/recommendations/feedback
+- Get the user's feedback on recommendations, newest first
+- Headers: X-User-ID (required)
+
+#### Add Recommendation Feedback
+POST /watchlist/recommendations/feedback
+- Mark a recommended title; takes effect on the next recommendations request
+- "not_interested" hides the title and steers away from its genres, keywords and people; "already_seen" only hides it; "more_like_this" boosts its traits and adds titles related to it
+- Feedback on the same title replaces earlier feedback
+- Headers: X-User-ID (required)
+- Body: {"media_type": "movie|tv", "media_id": number, "feedback": "not_interested|already_seen|more_like_this"}
+
+#### Remove Recommendation Feedback
+DELETE /watchlist/recommendations/feedback?media_type={type}&media_id={id}
+- Undo feedback on a title
+- Headers: X-User-ID (required)
+
+### Admin
+
+Admin endpoints require the X-Admin-Token header to match ADMIN_TOKEN and are
+disabled when ADMIN_TOKEN is unset.
+
+#### List Jobs
+GET /admin/jobs
+- List background jobs with their schedule, next run and last run
+
+#### Get Job History
+GET /admin/jobs/{name}/history
+- Get a job's most recent runs, newest first
+
+#### Run Job
+POST /admin/jobs/{name}/run
+- Start a job immediately; returns 409 if it's already running
+
+#### Export Watchlists
+GET /admin/watchlists/export
+- Export every user's watchlist items for the offline recommendation evaluation (go run ./cmd/evaluate)
+
+#### Experiment Report
+GET /admin/experiments
+- Get per-variant conversion for every recommendation experiment, the running one first: users shown recommendations, impressions, shown titles users added to their watchlist within EXPERIMENT_ATTRIBUTION_WINDOW, and conversion rates
 
 ## Response Format
 
@@ -234,7 +686,11 @@ Error responses include:
 ## Caching
 
 - Search results are cached for 30 minutes
+- Fetched titles are indexed locally and persisted under CACHE_DIR
 - Trending content is cached for 1 hour
 - Movie details are cached for 1 hour
+- Person details and filmographies are cached for 1 hour
 - Genres are cached for 24 hours
+- Lists are cached per list: popular 1 hour, top rated 24 hours, upcoming 6 hours, now playing 3 hours, airing TV 30 minutes
+- Genres, popular titles and trending people are refreshed in the background before they expire
 `
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// availabilityKey identifies a title's availability in one region
+type availabilityKey struct {
+	region string
+	ref    mediaRef
+}
+
+// AvailabilityStore keeps the last seen subscription providers of each title
+// per region so that changes can be detected between checks
+type AvailabilityStore struct {
+	mu        sync.Mutex
+	snapshots map[availabilityKey]map[int]models.Provider
+}
+
+// NewAvailabilityStore creates a new, empty availability store
+func NewAvailabilityStore() *AvailabilityStore {
+	return &AvailabilityStore{
+		snapshots: make(map[availabilityKey]map[int]models.Provider),
+	}
+}
+
+// Diff records the current providers of a title and returns the providers
+// that arrived and left since the previous snapshot. The first snapshot of a
+// title only sets the baseline.
+func (s *AvailabilityStore) Diff(region string, mediaType string, id int, current map[int]models.Provider) ([]models.Provider, []models.Provider) {
+	key := availabilityKey{region: region, ref: mediaRef{mediaType: mediaType, id: id}}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, seen := s.snapshots[key]
+	s.snapshots[key] = current
+	if !seen {
+		return nil, nil
+	}
+
+	var added, removed []models.Provider
+	for providerID, provider := range current {
+		if _, exists := previous[providerID]; !exists {
+			added = append(added, provider)
+		}
+	}
+	for providerID, provider := range previous {
+		if _, exists := current[providerID]; !exists {
+			removed = append(removed, provider)
+		}
+	}
+
+	sortProviders(added)
+	sortProviders(removed)
+
+	return added, removed
+}
+
+// retain forgets the snapshots of titles no longer watched
+func (s *AvailabilityStore) retain(keep map[availabilityKey][]watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.snapshots {
+		if _, watched := keep[key]; !watched {
+			delete(s.snapshots, key)
+		}
+	}
+}
+
+// Export returns every snapshot keyed by "region:type:id" for persisting
+func (s *AvailabilityStore) Export() map[string]map[int]models.Provider {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exported := make(map[string]map[int]models.Provider, len(s.snapshots))
+	for key, providers := range s.snapshots {
+		exported[fmt.Sprintf("%s:%s:%d", key.region, key.ref.mediaType, key.ref.id)] = providers
+	}
+	return exported
+}
+
+// Import restores snapshots exported by a previous run. Malformed keys are
+// skipped.
+func (s *AvailabilityStore) Import(snapshots map[string]map[int]models.Provider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for encoded, providers := range snapshots {
+		parts := strings.Split(encoded, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		id, err := strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+		if providers == nil {
+			providers = make(map[int]models.Provider)
+		}
+		s.snapshots[availabilityKey{region: parts[0], ref: mediaRef{mediaType: parts[1], id: id}}] = providers
+	}
+}
+
+// sortProviders orders providers by ID
+func sortProviders(providers []models.Provider) {
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].ID < providers[j].ID
+	})
+}
+
+// AvailabilityService watches where watchlisted titles can be streamed and
+// notifies users when a title arrives on or leaves one of their services
+type AvailabilityService struct {
+	tmdbService         *TMDBService
+	watchlistService    *WatchlistService
+	settingsService     *SettingsService
+	notificationService *NotificationService
+	store               *AvailabilityStore
+	path                string
+}
+
+// NewAvailabilityService creates a new availability service instance
+func NewAvailabilityService(tmdbService *TMDBService, watchlistService *WatchlistService, settingsService *SettingsService, notificationService *NotificationService) *AvailabilityService {
+	return &AvailabilityService{
+		tmdbService:         tmdbService,
+		watchlistService:    watchlistService,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+		store:               NewAvailabilityStore(),
+		path:                filepath.Join(config.AppConfig.Cache.Dir, "availability.json"),
+	}
+}
+
+// Load restores the availability baseline persisted by a previous run, so
+// that changes made while the server was down are still notified
+func (s *AvailabilityService) Load() error {
+	snapshots := make(map[string]map[int]models.Provider)
+	if err := utils.LoadJSONFile(s.path, &snapshots); err != nil {
+		return err
+	}
+	s.store.Import(snapshots)
+	return nil
+}
+
+// watcher is a user watching a title in a region
+type watcher struct {
+	userID     string
+	title      string
+	subscribed map[int]bool
+}
+
+// CheckAvailability rechecks every watchlisted title in the region of each
+// user with saved subscriptions and notifies them about changes
+func (s *AvailabilityService) CheckAvailability(ctx context.Context) error {
+	// Group users by the titles and regions they watch
+	watchers := make(map[availabilityKey][]watcher)
+	for userID, items := range s.watchlistService.GetAllWatchlistItems() {
+		settings := s.settingsService.GetSettings(ctx, userID)
+		if len(settings.ProviderIDs) == 0 {
+			continue
+		}
+
+		subscribed := make(map[int]bool, len(settings.ProviderIDs))
+		for _, id := range settings.ProviderIDs {
+			subscribed[id] = true
+		}
+
+		for _, item := range items {
+			key := availabilityKey{region: settings.Region, ref: itemRef(item)}
+			watchers[key] = append(watchers[key], watcher{
+				userID:     userID,
+				title:      item.Media.Title,
+				subscribed: subscribed,
+			})
+		}
+	}
+
+	// Check titles in a stable order
+	keys := make([]availabilityKey, 0, len(watchers))
+	for key := range watchers {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ref.id != keys[j].ref.id {
+			return keys[i].ref.id < keys[j].ref.id
+		}
+		if keys[i].ref.mediaType != keys[j].ref.mediaType {
+			return keys[i].ref.mediaType < keys[j].ref.mediaType
+		}
+		return keys[i].region < keys[j].region
+	})
+
+	failed := 0
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		providers, err := s.tmdbService.GetWatchProviders(ctx, key.ref.mediaType, key.ref.id)
+		if err != nil {
+			failed++
+			continue
+		}
+
+		// Only subscription services matter for alerts
+		current := make(map[int]models.Provider)
+		regional := providers.Results[key.region]
+		for _, list := range [][]models.Provider{regional.Flatrate, regional.Free, regional.Ads} {
+			for _, provider := range list {
+				current[provider.ID] = provider
+			}
+		}
+
+		added, removed := s.store.Diff(key.region, key.ref.mediaType, key.ref.id, current)
+		for _, w := range watchers[key] {
+			for _, provider := range added {
+				if w.subscribed[provider.ID] {
+					s.notify(ctx, w, key, provider, models.NotificationAvailabilityAdded)
+				}
+			}
+			for _, provider := range removed {
+				if w.subscribed[provider.ID] {
+					s.notify(ctx, w, key, provider, models.NotificationAvailabilityRemoved)
+				}
+			}
+		}
+	}
+
+	// Persist the baseline of the titles still watched
+	s.store.retain(watchers)
+	var errs []error
+	if err := utils.SaveJSONFile(s.path, s.store.Export()); err != nil {
+		errs = append(errs, err)
+	}
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf("failed to check availability for %d of %d titles", failed, len(keys)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// notify sends an availability change notification to a user
+func (s *AvailabilityService) notify(ctx context.Context, w watcher, key availabilityKey, provider models.Provider, notificationType string) {
+	title := w.title
+	if title == "" {
+		title = "A title on your watchlist"
+	}
+
+	notification := models.Notification{
+		Type:         notificationType,
+		MediaType:    key.ref.mediaType,
+		MediaID:      key.ref.id,
+		ProviderID:   provider.ID,
+		ProviderName: provider.Name,
+		Region:       key.region,
+	}
+	if notificationType == models.NotificationAvailabilityAdded {
+		notification.Title = "Now on " + provider.Name
+		notification.Message = fmt.Sprintf("%s is now available on %s", title, provider.Name)
+	} else {
+		notification.Title = "Leaving " + provider.Name
+		notification.Message = fmt.Sprintf("%s is no longer available on %s", title, provider.Name)
+	}
+
+	s.notificationService.Notify(ctx, w.userID, notification)
+}
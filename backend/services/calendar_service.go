@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// calendarDateLayout is the format of TMDB dates
+const calendarDateLayout = "2006-01-02"
+
+// Feed tokens live in the shared store under calendarFeedState so a feed URL
+// works on every replica, and changes hold calendarFeedLock
+const (
+	calendarFeedState = "calendar_feeds"
+	calendarFeedLock  = "calendar-feeds"
+)
+
+// calendarReleaseTypes maps the TMDB release types shown in the calendar to
+// event types
+var calendarReleaseTypes = map[int]string{
+	models.ReleaseTypeTheatricalLimited: models.CalendarTheatrical,
+	models.ReleaseTypeTheatrical:        models.CalendarTheatrical,
+	models.ReleaseTypeDigital:           models.CalendarDigital,
+	models.ReleaseTypePhysical:          models.CalendarPhysical,
+}
+
+// calendarTitle is a title whose upcoming dates go in a calendar
+type calendarTitle struct {
+	ref        mediaRef
+	title      string
+	posterPath string
+	source     string
+	personID   int
+	personName string
+}
+
+// calendarFeed is a user's secret feed token
+type calendarFeed struct {
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarService builds per-user calendars of upcoming movie releases and
+// episode air dates for watchlisted titles and followed people
+type CalendarService struct {
+	tmdbService      *TMDBService
+	watchlistService *WatchlistService
+	settingsService  *SettingsService
+	store            StateStore
+	locker           JobLocker
+}
+
+// NewCalendarService creates a new calendar service instance
+func NewCalendarService(tmdbService *TMDBService, watchlistService *WatchlistService, settingsService *SettingsService, store StateStore, locker JobLocker) *CalendarService {
+	return &CalendarService{
+		tmdbService:      tmdbService,
+		watchlistService: watchlistService,
+		settingsService:  settingsService,
+		store:            store,
+		locker:           locker,
+	}
+}
+
+// GetCalendar returns a user's releases and episodes from today through the
+// given number of days, using release dates in the user's region. Titles that
+// fail to load are left out.
+func (s *CalendarService) GetCalendar(ctx context.Context, userID string, days int) (*models.Calendar, error) {
+	settings := s.settingsService.GetSettings(ctx, userID)
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, days)
+
+	calendar := &models.Calendar{
+		UserID: userID,
+		Region: settings.Region,
+		From:   from.Format(calendarDateLayout),
+		To:     to.Format(calendarDateLayout),
+		Events: []models.CalendarEvent{},
+	}
+
+	// Watchlisted titles come first so they win over followed people's credits
+	var titles []calendarTitle
+	seen := make(map[mediaRef]bool)
+	if watchlist, err := s.watchlistService.GetWatchlist(ctx, userID); err == nil {
+		for _, item := range watchlist.Items {
+			ref := itemRef(item)
+			if !seen[ref] {
+				seen[ref] = true
+				titles = append(titles, calendarTitle{
+					ref:        ref,
+					title:      item.Media.Title,
+					posterPath: item.Media.PosterPath,
+					source:     models.CalendarSourceWatchlist,
+				})
+			}
+		}
+	}
+
+	// Followed people's upcoming movies become titles; new shows are premieres
+	var mu sync.Mutex
+	var events []models.CalendarEvent
+	followed := settings.FollowedPeople
+	peopleTitles := make([][]calendarTitle, len(followed))
+	runPool(ctx, len(followed), func(i int) {
+		personTitles, premieres, err := s.personUpcoming(ctx, followed[i], from, to)
+		if err != nil {
+			return
+		}
+		peopleTitles[i] = personTitles
+		mu.Lock()
+		events = append(events, premieres...)
+		mu.Unlock()
+	})
+	for _, personTitles := range peopleTitles {
+		for _, title := range personTitles {
+			if !seen[title.ref] {
+				seen[title.ref] = true
+				titles = append(titles, title)
+			}
+		}
+	}
+
+	// Fetch dates for every title
+	runPool(ctx, len(titles), func(i int) {
+		var titleEvents []models.CalendarEvent
+		var err error
+		if titles[i].ref.mediaType == models.MediaTypeTV {
+			titleEvents, err = s.episodeEvents(ctx, titles[i], from, to)
+		} else {
+			titleEvents, err = s.releaseEvents(ctx, titles[i], settings.Region, from, to)
+		}
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		events = append(events, titleEvents...)
+		mu.Unlock()
+	})
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Date != events[j].Date {
+			return events[i].Date < events[j].Date
+		}
+		if events[i].Title != events[j].Title {
+			return events[i].Title < events[j].Title
+		}
+		return events[i].EpisodeNumber < events[j].EpisodeNumber
+	})
+	calendar.Events = append(calendar.Events, events...)
+
+	return calendar, nil
+}
+
+// personUpcoming returns a person's unreleased movies and the premieres of
+// their shows within the window
+func (s *CalendarService) personUpcoming(ctx context.Context, personID int, from, to time.Time) ([]calendarTitle, []models.CalendarEvent, error) {
+	person, err := s.tmdbService.GetPersonDetails(ctx, personID)
+	if err != nil {
+		return nil, nil, err
+	}
+	credits, err := s.tmdbService.GetPersonCredits(ctx, personID, "date")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var titles []calendarTitle
+	var premieres []models.CalendarEvent
+	seen := make(map[mediaRef]bool)
+	for _, credit := range append(credits.Cast, credits.Crew...) {
+		ref := mediaRef{mediaType: credit.MediaType, id: credit.ID}
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+
+		switch credit.MediaType {
+		case models.MediaTypeMovie:
+			// Movies without a date yet may still have regional dates scheduled
+			if credit.ReleaseDate == "" || credit.ReleaseDate >= from.Format(calendarDateLayout) {
+				titles = append(titles, calendarTitle{
+					ref:        ref,
+					title:      credit.Title,
+					posterPath: credit.PosterPath,
+					source:     models.CalendarSourceFollowing,
+					personID:   person.ID,
+					personName: person.Name,
+				})
+			}
+		case models.MediaTypeTV:
+			if inCalendarWindow(credit.ReleaseDate, from, to) {
+				premieres = append(premieres, models.CalendarEvent{
+					UID:        fmt.Sprintf("tv-%d-premiere", credit.ID),
+					Date:       credit.ReleaseDate,
+					Type:       models.CalendarPremiere,
+					Source:     models.CalendarSourceFollowing,
+					MediaType:  models.MediaTypeTV,
+					MediaID:    credit.ID,
+					Title:      credit.Title,
+					PosterPath: credit.PosterPath,
+					PersonID:   person.ID,
+					PersonName: person.Name,
+				})
+			}
+		}
+	}
+
+	return titles, premieres, nil
+}
+
+// releaseEvents returns a movie's theatrical, digital and physical releases
+// in a region within the window
+func (s *CalendarService) releaseEvents(ctx context.Context, title calendarTitle, region string, from, to time.Time) ([]models.CalendarEvent, error) {
+	releases, err := s.tmdbService.GetReleaseDates(ctx, title.ref.id)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.CalendarEvent
+	seen := make(map[string]bool)
+	for _, release := range releases[region] {
+		eventType, shown := calendarReleaseTypes[release.Type]
+		if !shown || !inCalendarWindow(release.Date, from, to) {
+			continue
+		}
+		// Limited and wide theatrical releases on the same day are one event
+		uid := fmt.Sprintf("movie-%d-%s-%s-%s", title.ref.id, region, eventType, release.Date)
+		if seen[uid] {
+			continue
+		}
+		seen[uid] = true
+
+		note := release.Note
+		if note == "" && release.Type == models.ReleaseTypeTheatricalLimited {
+			note = "Limited release"
+		}
+		events = append(events, models.CalendarEvent{
+			UID:        uid,
+			Date:       release.Date,
+			Type:       eventType,
+			Source:     title.source,
+			MediaType:  models.MediaTypeMovie,
+			MediaID:    title.ref.id,
+			Title:      title.title,
+			PosterPath: title.posterPath,
+			PersonID:   title.personID,
+			PersonName: title.personName,
+			Note:       note,
+		})
+	}
+
+	return events, nil
+}
+
+// episodeEvents returns a show's episodes airing within the window, starting
+// with the season of its next episode
+func (s *CalendarService) episodeEvents(ctx context.Context, title calendarTitle, from, to time.Time) ([]models.CalendarEvent, error) {
+	show, err := s.tmdbService.GetTVDetails(ctx, title.ref.id)
+	if err != nil {
+		return nil, err
+	}
+	if show.TVDetails == nil || show.NextEpisodeToAir == nil {
+		return nil, nil
+	}
+
+	episodes := []models.Episode{*show.NextEpisodeToAir}
+	if season, err := s.tmdbService.GetTVSeason(ctx, show.ID, show.NextEpisodeToAir.SeasonNumber); err == nil {
+		episodes = season.Episodes
+	}
+
+	var events []models.CalendarEvent
+	for _, episode := range episodes {
+		if !inCalendarWindow(episode.AirDate, from, to) {
+			continue
+		}
+		events = append(events, models.CalendarEvent{
+			UID:           fmt.Sprintf("tv-%d-s%de%d", show.ID, episode.SeasonNumber, episode.EpisodeNumber),
+			Date:          episode.AirDate,
+			Type:          models.CalendarEpisode,
+			Source:        title.source,
+			MediaType:     models.MediaTypeTV,
+			MediaID:       show.ID,
+			Title:         show.Title,
+			PosterPath:    show.PosterPath,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			EpisodeName:   episode.Name,
+		})
+	}
+
+	return events, nil
+}
+
+// inCalendarWindow reports whether a YYYY-MM-DD date falls within [from, to]
+func inCalendarWindow(date string, from, to time.Time) bool {
+	day, err := time.Parse(calendarDateLayout, date)
+	if err != nil {
+		return false
+	}
+	return !day.Before(from) && !day.After(to)
+}
+
+// GetFeedToken returns the secret token of a user's calendar feed, creating
+// one the first time
+func (s *CalendarService) GetFeedToken(ctx context.Context, userID string) (string, time.Time, error) {
+	feeds, err := s.loadFeeds(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if feed, exists := feeds[userID]; exists {
+		return feed.Token, feed.CreatedAt, nil
+	}
+
+	return s.newFeedToken(ctx, userID, false)
+}
+
+// RotateFeedToken replaces a user's feed token so the old feed URL stops working
+func (s *CalendarService) RotateFeedToken(ctx context.Context, userID string) (string, time.Time, error) {
+	return s.newFeedToken(ctx, userID, true)
+}
+
+// GetFeedUser returns the user a feed token belongs to
+func (s *CalendarService) GetFeedUser(ctx context.Context, token string) (string, bool) {
+	feeds, err := s.loadFeeds(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	for userID, feed := range feeds {
+		if feed.Token == token {
+			return userID, true
+		}
+	}
+	return "", false
+}
+
+// loadFeeds reads every user's feed from the shared store
+func (s *CalendarService) loadFeeds(ctx context.Context) (map[string]calendarFeed, error) {
+	feeds := make(map[string]calendarFeed)
+	if err := s.store.Load(ctx, calendarFeedState, &feeds); err != nil {
+		return nil, fmt.Errorf("failed to load calendar feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+// newFeedToken creates and saves a random feed token under the shared lock.
+// Unless replace is set, a token another request created meanwhile is kept.
+func (s *CalendarService) newFeedToken(ctx context.Context, userID string, replace bool) (string, time.Time, error) {
+	release, err := lockState(ctx, s.locker, calendarFeedLock)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer release()
+
+	feeds, err := s.loadFeeds(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if feed, exists := feeds[userID]; exists && !replace {
+		return feed.Token, feed.CreatedAt, nil
+	}
+
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	feed := calendarFeed{Token: hex.EncodeToString(buf), CreatedAt: time.Now()}
+	feeds[userID] = feed
+
+	if err := s.store.Save(ctx, calendarFeedState, feeds); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to save calendar feeds: %w", err)
+	}
+
+	return feed.Token, feed.CreatedAt, nil
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// servableStrategy is a recommender strategy GetRecommendations can serve:
+// one that ranks TMDB candidates with a set of weights
+type servableStrategy interface {
+	RecommenderStrategy
+	Weights() RecommendationWeights
+}
+
+// experimentExposure is what a user was shown in an experiment and which of
+// those titles they went on to add to their watchlist
+type experimentExposure struct {
+	Variant string               `json:"variant"`
+	Shown   map[string]time.Time `json:"shown"` // "type:id" -> last shown
+	Added   map[string]time.Time `json:"added"` // "type:id" -> added
+}
+
+// experimentLog is everything recorded for one experiment
+type experimentLog struct {
+	StartedAt   time.Time                      `json:"started_at"`
+	Strategies  map[string]string              `json:"strategies"`  // variant -> strategy
+	Impressions map[string]int                 `json:"impressions"` // variant -> recommendations shown
+	Users       map[string]*experimentExposure `json:"users"`       // userID -> exposure
+}
+
+// ExperimentService runs an A/B experiment between recommender strategies.
+// Users are bucketed into a variant by a hash of the experiment name and
+// their ID, so they stay in the same arm across requests and replicas. It
+// records the recommendations each arm shows and the shown titles users add
+// to their watchlist afterwards.
+type ExperimentService struct {
+	path   string
+	window time.Duration
+
+	// Set by Load; empty name means no experiment is running
+	name     string
+	variants []models.ExperimentVariant
+	weights  map[string]RecommendationWeights // variant -> weights
+	total    int
+
+	mu    sync.Mutex
+	logs  map[string]*experimentLog // experiment -> log
+	dirty bool
+}
+
+// NewExperimentService creates a new experiment service instance
+func NewExperimentService() *ExperimentService {
+	return &ExperimentService{
+		path:   filepath.Join(config.AppConfig.Cache.Dir, "experiments.json"),
+		window: config.AppConfig.Experiment.AttributionWindow,
+		logs:   make(map[string]*experimentLog),
+	}
+}
+
+// Load restores the events persisted by a previous run and starts the
+// configured experiment. An invalid experiment is not started.
+func (s *ExperimentService) Load() error {
+	logs := make(map[string]*experimentLog)
+	if err := utils.LoadJSONFile(s.path, &logs); err != nil {
+		return err
+	}
+	if logs != nil {
+		s.mu.Lock()
+		s.logs = logs
+		s.mu.Unlock()
+	}
+
+	name := config.AppConfig.Experiment.Name
+	if name == "" {
+		return nil
+	}
+	variants, err := parseExperimentVariants(config.AppConfig.Experiment.Variants)
+	if err != nil {
+		return fmt.Errorf("invalid RECOMMENDATION_VARIANTS: %w", err)
+	}
+
+	weights := make(map[string]RecommendationWeights, len(variants))
+	total := 0
+	for _, variant := range variants {
+		strategy, err := NewRecommenderStrategy(variant.Strategy)
+		if err != nil {
+			return err
+		}
+		servable, ok := strategy.(servableStrategy)
+		if !ok {
+			return fmt.Errorf("recommender strategy %q can't be served", variant.Strategy)
+		}
+		weights[variant.Name] = servable.Weights()
+		total += variant.Weight
+	}
+
+	s.name = name
+	s.variants = variants
+	s.weights = weights
+	s.total = total
+
+	return nil
+}
+
+// parseExperimentVariants parses comma-separated name=strategy[:weight] arms
+func parseExperimentVariants(spec string) ([]models.ExperimentVariant, error) {
+	var variants []models.ExperimentVariant
+	seen := make(map[string]bool)
+	for _, arm := range strings.Split(spec, ",") {
+		name, strategy, found := strings.Cut(strings.TrimSpace(arm), "=")
+		if !found || name == "" || strategy == "" {
+			return nil, fmt.Errorf("expected name=strategy[:weight], got %q", arm)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate variant %q", name)
+		}
+		seen[name] = true
+
+		variant := models.ExperimentVariant{Name: name, Strategy: strategy, Weight: 1}
+		if strategy, weight, found := strings.Cut(strategy, ":"); found {
+			parsed, err := strconv.Atoi(weight)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid weight for variant %q", name)
+			}
+			variant.Strategy = strategy
+			variant.Weight = parsed
+		}
+		variants = append(variants, variant)
+	}
+
+	if len(variants) < 2 {
+		return nil, fmt.Errorf("an experiment needs at least two variants")
+	}
+	return variants, nil
+}
+
+// Assign returns the variant a user is bucketed into and the weights it
+// serves. Without a running experiment there is no assignment and the
+// default weights are served.
+func (s *ExperimentService) Assign(userID string) (*models.ExperimentAssignment, RecommendationWeights) {
+	if s.name == "" {
+		return nil, DefaultRecommendationWeights()
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(s.name + ":" + userID))
+	bucket := int(hash.Sum32() % uint32(s.total))
+	for _, variant := range s.variants {
+		if bucket < variant.Weight {
+			assignment := &models.ExperimentAssignment{
+				Experiment: s.name,
+				Variant:    variant.Name,
+				Strategy:   variant.Strategy,
+			}
+			return assignment, s.weights[variant.Name]
+		}
+		bucket -= variant.Weight
+	}
+
+	return nil, DefaultRecommendationWeights()
+}
+
+// RecordImpressions records the recommendations a user was shown under an
+// assignment
+func (s *ExperimentService) RecordImpressions(userID string, assignment *models.ExperimentAssignment, recommendations []models.WatchlistRecommendation) {
+	if assignment == nil || len(recommendations) == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log, exists := s.logs[assignment.Experiment]
+	if !exists {
+		log = &experimentLog{
+			StartedAt:   now,
+			Strategies:  make(map[string]string, len(s.variants)),
+			Impressions: make(map[string]int, len(s.variants)),
+			Users:       make(map[string]*experimentExposure),
+		}
+		for _, variant := range s.variants {
+			log.Strategies[variant.Name] = variant.Strategy
+		}
+		s.logs[assignment.Experiment] = log
+	}
+
+	exposure, exists := log.Users[userID]
+	if !exists {
+		exposure = &experimentExposure{
+			Variant: assignment.Variant,
+			Shown:   make(map[string]time.Time),
+			Added:   make(map[string]time.Time),
+		}
+		log.Users[userID] = exposure
+	}
+
+	log.Impressions[assignment.Variant] += len(recommendations)
+	for _, recommendation := range recommendations {
+		exposure.Shown[experimentKey(recommendation.Media.MediaType, recommendation.Media.ID)] = now
+	}
+	s.dirty = true
+}
+
+// RecordAdd attributes a watchlist add to the running experiment when the
+// title was recommended to the user within the attribution window
+func (s *ExperimentService) RecordAdd(userID string, mediaType string, mediaID int) {
+	if s.name == "" {
+		return
+	}
+
+	key := experimentKey(mediaType, mediaID)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log, exists := s.logs[s.name]
+	if !exists {
+		return
+	}
+	exposure, exists := log.Users[userID]
+	if !exists {
+		return
+	}
+	shownAt, shown := exposure.Shown[key]
+	if _, added := exposure.Added[key]; !shown || added || now.Sub(shownAt) > s.window {
+		return
+	}
+
+	exposure.Added[key] = now
+	s.dirty = true
+}
+
+// experimentKey identifies a title in experiment logs, treating untyped
+// titles as movies
+func experimentKey(mediaType string, mediaID int) string {
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	return fmt.Sprintf("%s:%d", mediaType, mediaID)
+}
+
+// Report returns how each arm of every recorded experiment converted, the
+// most recently started experiment first
+func (s *ExperimentService) Report() []models.ExperimentReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reports := make([]models.ExperimentReport, 0, len(s.logs))
+	for name, log := range s.logs {
+		byVariant := make(map[string]*models.ExperimentVariantReport, len(log.Strategies))
+		variantNames := make([]string, 0, len(log.Strategies))
+		for variant, strategy := range log.Strategies {
+			byVariant[variant] = &models.ExperimentVariantReport{
+				Variant:     variant,
+				Strategy:    strategy,
+				Impressions: log.Impressions[variant],
+			}
+			variantNames = append(variantNames, variant)
+		}
+		sort.Strings(variantNames)
+
+		for _, exposure := range log.Users {
+			report, exists := byVariant[exposure.Variant]
+			if !exists {
+				continue
+			}
+			report.Users++
+			report.UniqueImpressions += len(exposure.Shown)
+			report.Adds += len(exposure.Added)
+			if len(exposure.Added) > 0 {
+				report.ConvertedUsers++
+			}
+		}
+
+		experiment := models.ExperimentReport{
+			Experiment: name,
+			Active:     name == s.name,
+			StartedAt:  log.StartedAt,
+			Variants:   make([]models.ExperimentVariantReport, 0, len(variantNames)),
+		}
+		for _, variant := range variantNames {
+			report := byVariant[variant]
+			if report.UniqueImpressions > 0 {
+				report.ConversionRate = float64(report.Adds) / float64(report.UniqueImpressions)
+			}
+			if report.Users > 0 {
+				report.UserConversionRate = float64(report.ConvertedUsers) / float64(report.Users)
+			}
+			experiment.Variants = append(experiment.Variants, *report)
+		}
+		reports = append(reports, experiment)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].StartedAt.After(reports[j].StartedAt)
+	})
+
+	return reports
+}
+
+// Save persists the experiment logs next to the cache if they changed since
+// the last save
+func (s *ExperimentService) Save(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+	if err := utils.SaveJSONFile(s.path, s.logs); err != nil {
+		return err
+	}
+	s.dirty = false
+
+	return nil
+}
+
+// Close persists any unsaved experiment events
+func (s *ExperimentService) Close() error {
+	return s.Save(context.Background())
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// No person keeps more than maxPersonUpdates updates. Credits that first show
+// up already released count as new releases only within recentReleaseWindow;
+// older ones are back catalog TMDB filled in.
+const (
+	maxPersonUpdates    = 50
+	recentReleaseWindow = 30 * 24 * time.Hour
+)
+
+// followedPerson is the last seen filmography of a followed person
+type followedPerson struct {
+	Name        string            `json:"name"`
+	ProfilePath string            `json:"profile_path"`
+	Credits     map[string]string `json:"credits"` // "type:id" -> release date
+	CheckedAt   time.Time         `json:"checked_at"`
+}
+
+// followState is what the follow service persists between runs
+type followState struct {
+	Follows map[string][]int              `json:"follows"` // userID -> followed person IDs
+	People  map[int]*followedPerson       `json:"people"`
+	Updates map[int][]models.PersonUpdate `json:"updates"` // newest first
+}
+
+// FollowService watches the filmographies of followed people and tells their
+// followers about newly announced and newly released projects
+type FollowService struct {
+	tmdbService         *TMDBService
+	settingsService     *SettingsService
+	notificationService *NotificationService
+	path                string
+	saveMu              sync.Mutex // orders saves so an older state never lands last
+
+	mu      sync.RWMutex
+	people  map[int]*followedPerson
+	updates map[int][]models.PersonUpdate // personID -> updates, newest first
+}
+
+// NewFollowService creates a new follow service instance
+func NewFollowService(tmdbService *TMDBService, settingsService *SettingsService, notificationService *NotificationService) *FollowService {
+	return &FollowService{
+		tmdbService:         tmdbService,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+		path:                filepath.Join(config.AppConfig.Cache.Dir, "follow_updates.json"),
+		people:              make(map[int]*followedPerson),
+		updates:             make(map[int][]models.PersonUpdate),
+	}
+}
+
+// Load restores the follows, filmographies and updates persisted by a
+// previous run
+func (s *FollowService) Load() error {
+	state := followState{}
+	if err := utils.LoadJSONFile(s.path, &state); err != nil {
+		return err
+	}
+
+	if state.Follows != nil {
+		s.settingsService.RestoreFollowedPeople(state.Follows)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state.People != nil {
+		s.people = state.People
+	}
+	if state.Updates != nil {
+		s.updates = state.Updates
+	}
+	return nil
+}
+
+// Follow adds a person to the people a user follows and persists it
+func (s *FollowService) Follow(ctx context.Context, userID string, personID int) (models.UserSettings, error) {
+	settings := s.settingsService.FollowPerson(ctx, userID, personID)
+	return settings, s.save()
+}
+
+// Unfollow removes a person from the people a user follows and persists it
+func (s *FollowService) Unfollow(ctx context.Context, userID string, personID int) (models.UserSettings, error) {
+	settings := s.settingsService.UnfollowPerson(ctx, userID, personID)
+	return settings, s.save()
+}
+
+// CheckFollowedPeople compares the filmography of every followed person with
+// the previous check and notifies followers about new projects. The first
+// check of a person only sets the baseline.
+func (s *FollowService) CheckFollowedPeople(ctx context.Context) error {
+	followers := s.settingsService.GetFollowers()
+
+	// Check people in a stable order
+	personIDs := make([]int, 0, len(followers))
+	for personID := range followers {
+		personIDs = append(personIDs, personID)
+	}
+	sort.Ints(personIDs)
+
+	var errs []error
+	failed := 0
+	for _, personID := range personIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		updates, err := s.checkPerson(ctx, personID)
+		if err != nil {
+			failed++
+			continue
+		}
+		for _, update := range updates {
+			for _, userID := range followers[personID] {
+				s.notify(ctx, userID, update)
+			}
+		}
+	}
+
+	// Forget people nobody follows anymore. No followers at all more likely
+	// means the follows haven't been restored than that everyone unfollowed.
+	if len(followers) > 0 {
+		s.mu.Lock()
+		for personID := range s.people {
+			if _, followed := followers[personID]; !followed {
+				delete(s.people, personID)
+				delete(s.updates, personID)
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	if err := s.save(); err != nil {
+		errs = append(errs, err)
+	}
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf("failed to check %d of %d followed people", failed, len(personIDs)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// save persists the follows, filmographies and updates
+func (s *FollowService) save() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	state := followState{Follows: s.settingsService.GetFollowedPeople()}
+	s.mu.RLock()
+	state.People = make(map[int]*followedPerson, len(s.people))
+	state.Updates = make(map[int][]models.PersonUpdate, len(s.updates))
+	for personID, person := range s.people {
+		state.People[personID] = person
+	}
+	for personID, updates := range s.updates {
+		state.Updates[personID] = updates
+	}
+	s.mu.RUnlock()
+
+	return utils.SaveJSONFile(s.path, state)
+}
+
+// checkPerson fetches a person's filmography, records it and returns the
+// projects that were announced or released since the previous check
+func (s *FollowService) checkPerson(ctx context.Context, personID int) ([]models.PersonUpdate, error) {
+	person, err := s.tmdbService.GetPersonDetails(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	credits, err := s.tmdbService.GetPersonCredits(ctx, personID, "date")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := now.Format(calendarDateLayout)
+	recent := now.Add(-recentReleaseWindow).Format(calendarDateLayout)
+
+	// Merge cast and crew credits of the same title
+	current := make(map[string]string)
+	byKey := make(map[string]*models.PersonUpdate)
+	var keys []string
+	for _, credit := range append(credits.Cast, credits.Crew...) {
+		key := fmt.Sprintf("%s:%d", credit.MediaType, credit.ID)
+		if update, exists := byKey[key]; exists {
+			if update.Job == "" {
+				update.Job = credit.Job
+			}
+			continue
+		}
+		current[key] = credit.ReleaseDate
+		keys = append(keys, key)
+		byKey[key] = &models.PersonUpdate{
+			PersonID:          person.ID,
+			PersonName:        person.Name,
+			PersonProfilePath: person.ProfilePath,
+			MediaType:         credit.MediaType,
+			MediaID:           credit.ID,
+			Title:             credit.Title,
+			PosterPath:        credit.PosterPath,
+			ReleaseDate:       credit.ReleaseDate,
+			Character:         credit.Character,
+			Job:               credit.Job,
+			DetectedAt:        now,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, checked := s.people[personID]
+	s.people[personID] = &followedPerson{
+		Name:        person.Name,
+		ProfilePath: person.ProfilePath,
+		Credits:     current,
+		CheckedAt:   now,
+	}
+	if !checked {
+		return nil, nil
+	}
+
+	var updates []models.PersonUpdate
+	for _, key := range keys {
+		date := current[key]
+		previousDate, known := previous.Credits[key]
+
+		updateType := ""
+		switch {
+		case !known && (date == "" || date > today):
+			updateType = models.PersonUpdateAnnounced
+		case !known && date >= recent:
+			updateType = models.PersonUpdateReleased
+		case known && date != "" && date <= today && (previousDate == "" || previousDate > today):
+			updateType = models.PersonUpdateReleased
+		}
+		if updateType == "" {
+			continue
+		}
+
+		update := *byKey[key]
+		update.Type = updateType
+		update.ID = fmt.Sprintf("%d:%s:%s", personID, key, updateType)
+		updates = append(updates, update)
+	}
+
+	if len(updates) > 0 {
+		all := append(updates, s.updates[personID]...)
+		if len(all) > maxPersonUpdates {
+			all = all[:maxPersonUpdates]
+		}
+		s.updates[personID] = all
+	}
+
+	return updates, nil
+}
+
+// notify sends a new project notification to a follower
+func (s *FollowService) notify(ctx context.Context, userID string, update models.PersonUpdate) {
+	notification := models.Notification{
+		MediaType:  update.MediaType,
+		MediaID:    update.MediaID,
+		PersonID:   update.PersonID,
+		PersonName: update.PersonName,
+	}
+	if update.Type == models.PersonUpdateAnnounced {
+		notification.Type = models.NotificationPersonAnnounced
+		notification.Title = "New from " + update.PersonName
+		notification.Message = fmt.Sprintf("%s has a new project: %s", update.PersonName, update.Title)
+		if update.ReleaseDate != "" {
+			notification.Message += fmt.Sprintf(" (%s)", update.ReleaseDate)
+		}
+	} else {
+		notification.Type = models.NotificationPersonReleased
+		notification.Title = "Out now from " + update.PersonName
+		notification.Message = fmt.Sprintf("%s with %s is out now", update.Title, update.PersonName)
+	}
+
+	s.notificationService.Notify(ctx, userID, notification)
+}
+
+// GetFeed returns one page of updates about the people a user follows, newest
+// first, along with the total number of updates
+func (s *FollowService) GetFeed(ctx context.Context, userID string, page, perPage int) ([]models.PersonUpdate, int) {
+	settings := s.settingsService.GetSettings(ctx, userID)
+
+	s.mu.RLock()
+	var feed []models.PersonUpdate
+	for _, personID := range settings.FollowedPeople {
+		feed = append(feed, s.updates[personID]...)
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(feed, func(i, j int) bool {
+		if !feed[i].DetectedAt.Equal(feed[j].DetectedAt) {
+			return feed[i].DetectedAt.After(feed[j].DetectedAt)
+		}
+		return feed[i].ID < feed[j].ID
+	})
+
+	total := len(feed)
+	start := (page - 1) * perPage
+	if start >= total {
+		return []models.PersonUpdate{}, total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	return feed[start:end], total
+}
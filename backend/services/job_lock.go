@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// JobLocker provides a lock shared by every replica so an exclusive job only
+// runs on one of them at a time
+type JobLocker interface {
+	// TryLock acquires the named lock without waiting. The lock expires after
+	// ttl even if it's never released, so a crashed replica can't hold it forever.
+	TryLock(ctx context.Context, name string, ttl time.Duration) (release func(), acquired bool, err error)
+}
+
+// Shared state changes hold a lock so replicas don't overwrite each other.
+// It's waited for rather than skipped like a job's.
+const (
+	stateLockTTL   = 10 * time.Second
+	stateLockWait  = 5 * time.Second
+	stateLockRetry = 25 * time.Millisecond
+)
+
+// lockState takes the named lock, waiting while another change holds it
+func lockState(ctx context.Context, locker JobLocker, name string) (func(), error) {
+	deadline := time.Now().Add(stateLockWait)
+	for {
+		release, acquired, err := locker.TryLock(ctx, name, stateLockTTL)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return release, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock %s", name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(stateLockRetry):
+		}
+	}
+}
+
+// redisUnlockScript deletes a lock only if it still holds the caller's token,
+// so a lock that expired and was taken by another replica isn't released
+const redisUnlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
+
+// RedisJobLocker implements JobLocker with expiring keys in the Redis server
+// every replica shares
+type RedisJobLocker struct {
+	client *utils.RedisClient
+	prefix string
+	owner  string
+}
+
+// NewRedisJobLocker creates a locker that keeps its locks under prefix
+func NewRedisJobLocker(client *utils.RedisClient, prefix string) *RedisJobLocker {
+	return &RedisJobLocker{
+		client: client,
+		prefix: prefix,
+		owner:  lockOwner(),
+	}
+}
+
+// TryLock acquires the named lock if no other replica holds an unexpired one
+func (l *RedisJobLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error) {
+	key := l.prefix + name
+	token := fmt.Sprintf("%s:%d", l.owner, time.Now().UnixNano())
+
+	_, err := l.client.Do(ctx, "SET", key, token, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if errors.Is(err, utils.ErrRedisNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	release := func() {
+		// The job's context may be done by now
+		l.client.Do(context.Background(), "EVAL", redisUnlockScript, "1", key, token)
+	}
+	return release, true, nil
+}
+
+// FileJobLocker implements JobLocker with lock files in a local directory.
+// Replicas on other hosts can't see its locks, so it only suits a single
+// instance.
+type FileJobLocker struct {
+	dir   string
+	owner string
+}
+
+// NewFileJobLocker creates a locker that keeps its lock files in dir
+func NewFileJobLocker(dir string) *FileJobLocker {
+	return &FileJobLocker{
+		dir:   dir,
+		owner: lockOwner(),
+	}
+}
+
+// lockOwner identifies this process in lock tokens
+func lockOwner() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// TryLock acquires the named lock if no other replica holds an unexpired one
+func (l *FileJobLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error) {
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return nil, false, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	l.pruneExpired()
+
+	path := filepath.Join(l.dir, name+".lock")
+	token := fmt.Sprintf("%s:%d", l.owner, time.Now().UnixNano())
+	contents := fmt.Sprintf("%d %s", time.Now().Add(ttl).Unix(), token)
+
+	// Try twice: the second attempt follows removing an expired lock
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, writeErr := file.WriteString(contents)
+			closeErr := file.Close()
+			if writeErr != nil || closeErr != nil {
+				os.Remove(path)
+				return nil, false, fmt.Errorf("failed to write lock %s: %w", name, errors.Join(writeErr, closeErr))
+			}
+			return func() { l.release(path, token) }, true, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, false, fmt.Errorf("failed to create lock %s: %w", name, err)
+		}
+
+		expiresAt, _, err := readLockFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, false, err
+		}
+		if time.Now().Before(expiresAt) {
+			return nil, false, nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, false, fmt.Errorf("failed to remove expired lock %s: %w", name, err)
+		}
+	}
+
+	return nil, false, nil
+}
+
+// pruneExpired removes expired lock files. Slot locks are never released,
+// so without this they'd pile up in the lock directory.
+func (l *FileJobLocker) pruneExpired() {
+	paths, _ := filepath.Glob(filepath.Join(l.dir, "*.lock"))
+	for _, path := range paths {
+		if expiresAt, _, err := readLockFile(path); err == nil && time.Now().After(expiresAt) {
+			os.Remove(path)
+		}
+	}
+}
+
+// release removes a lock file if it's still held by token
+func (l *FileJobLocker) release(path, token string) {
+	if _, owner, err := readLockFile(path); err == nil && owner == token {
+		os.Remove(path)
+	}
+}
+
+// readLockFile reads a lock file's expiry and owner token
+func readLockFile(path string) (time.Time, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	expiry, owner, _ := strings.Cut(string(data), " ")
+	seconds, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		// A half-written lock file is treated as expired
+		return time.Time{}, owner, nil
+	}
+
+	return time.Unix(seconds, 0), owner, nil
+}
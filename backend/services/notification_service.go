@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// maxInboxSize caps how many notifications are kept per user
+const maxInboxSize = 200
+
+// NotificationService stores user notifications
+type NotificationService struct {
+	// In a real application, you would have a database here
+	mu     sync.RWMutex
+	nextID int
+	inbox  map[string][]models.Notification // userID -> notifications, newest first
+}
+
+// NewNotificationService creates a new notification service instance
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		inbox: make(map[string][]models.Notification),
+	}
+}
+
+// Notify adds a notification to a user's inbox
+func (s *NotificationService) Notify(ctx context.Context, userID string, notification models.Notification) models.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	notification.ID = s.nextID
+	notification.UserID = userID
+	notification.Read = false
+	notification.CreatedAt = time.Now()
+
+	inbox := append([]models.Notification{notification}, s.inbox[userID]...)
+	if len(inbox) > maxInboxSize {
+		inbox = inbox[:maxInboxSize]
+	}
+	s.inbox[userID] = inbox
+
+	return notification
+}
+
+// GetNotifications returns a user's notifications, newest first, along with
+// the number of unread ones
+func (s *NotificationService) GetNotifications(ctx context.Context, userID string, unreadOnly bool) ([]models.Notification, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	notifications := []models.Notification{}
+	unread := 0
+	for _, notification := range s.inbox[userID] {
+		if !notification.Read {
+			unread++
+		} else if unreadOnly {
+			continue
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, unread
+}
+
+// MarkRead marks notifications as read and returns how many changed. With no
+// IDs every notification is marked as read.
+func (s *NotificationService) MarkRead(ctx context.Context, userID string, ids []int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	selected := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	changed := 0
+	inbox := s.inbox[userID]
+	for i := range inbox {
+		if !inbox[i].Read && (len(ids) == 0 || selected[inbox[i].ID]) {
+			inbox[i].Read = true
+			changed++
+		}
+	}
+
+	return changed
+}
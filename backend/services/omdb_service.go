@@ -274,7 +274,7 @@ func (s *OMDBService) ExtractRatings(omdbResp *OMDBMovieResponse) models.Ratings
 }
 
 // EnrichMovieWithOMDBData enriches a movie with OMDB data
-func (s *OMDBService) EnrichMovieWithOMDBData(ctx context.Context, movie *models.Movie) error {
+func (s *OMDBService) EnrichMovieWithOMDBData(ctx context.Context, movie *models.Media) error {
 	// Try to get OMDB data by title and year
 	year := utils.ParseYear(movie.ReleaseDate)
 	yearStr := ""
@@ -333,6 +333,12 @@ func (s *OMDBService) EnrichMovieWithOMDBData(ctx context.Context, movie *models
 	return nil
 }
 
+// CleanupCache removes expired entries from the cache
+func (s *OMDBService) CleanupCache(ctx context.Context) error {
+	s.cache.Cleanup()
+	return nil
+}
+
 // Close closes the service and cleans up resources
 func (s *OMDBService) Close() {
 	if s.client != nil {
@@ -0,0 +1,584 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// Watch party tuning
+const (
+	partyTTL               = 24 * time.Hour
+	defaultPartyCandidates = 10
+	maxPartyCandidates     = 20
+	maxPartyParticipants   = 50
+)
+
+var (
+	// ErrPartyNotFound is returned when no watch party that hasn't expired
+	// has an invite code
+	ErrPartyNotFound = errors.New("watch party not found")
+	// ErrPartyClosed is returned when voting on or joining a closed party
+	ErrPartyClosed = errors.New("watch party is closed")
+	// ErrPartyForbidden is returned when someone other than the host tries
+	// to close a party
+	ErrPartyForbidden = errors.New("only the host can close a watch party")
+	// ErrInvalidParty is returned when a watch party or ballot can't be used
+	ErrInvalidParty = errors.New("invalid watch party request")
+)
+
+// Watch parties live in the shared store under partyState, and every change
+// holds the shared partyLock so replicas don't overwrite each other. Each
+// replica polls the store every partyPoll to pass other replicas' changes to
+// its listeners and to end streams of parties that expired.
+const (
+	partyState = "watch_parties"
+	partyLock  = "watch-parties"
+	partyPoll  = 2 * time.Second
+)
+
+// partyRecord is a watch party as kept in the shared store, with its secret
+// ballots
+type partyRecord struct {
+	Party   models.WatchParty `json:"party"`
+	Ballots map[string][]int  `json:"ballots"` // userID -> ranking
+	Version int               `json:"version"` // bumped on every change
+}
+
+// PartyService runs watch parties: a host gathers candidate titles, invites
+// participants with a link, everyone ranks the candidates and the results
+// are recounted and pushed to listeners after every change
+type PartyService struct {
+	watchlistService *WatchlistService
+	pickService      *PickService
+	store            StateStore
+	locker           JobLocker
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan models.WatchParty]bool // invite code -> listeners on this replica
+	versions    map[string]int                             // invite code -> version last sent to them
+	watching    bool
+	done        chan struct{}
+}
+
+// NewPartyService creates a new watch party service instance
+func NewPartyService(watchlistService *WatchlistService, pickService *PickService, store StateStore, locker JobLocker) *PartyService {
+	return &PartyService{
+		watchlistService: watchlistService,
+		pickService:      pickService,
+		store:            store,
+		locker:           locker,
+		subscribers:      make(map[string]map[chan models.WatchParty]bool),
+		versions:         make(map[string]int),
+		done:             make(chan struct{}),
+	}
+}
+
+// CreateParty starts a watch party hosted by a user. Candidates come from
+// the titles listed in the request, the to-watch titles of a watchlist the
+// host owns or that is public, and the picker's shortlist, in that order.
+func (s *PartyService) CreateParty(ctx context.Context, hostID string, request models.WatchPartyCreateRequest) (*models.WatchParty, error) {
+	method := request.Method
+	if method == "" {
+		method = models.VotingRankedChoice
+	}
+	if method != models.VotingBorda && method != models.VotingRankedChoice {
+		return nil, fmt.Errorf("%w: method must be borda or ranked_choice", ErrInvalidParty)
+	}
+	limit := request.MaxCandidates
+	if limit <= 0 {
+		limit = defaultPartyCandidates
+	}
+	if limit > maxPartyCandidates {
+		limit = maxPartyCandidates
+	}
+
+	// Gather candidates
+	media, err := s.partyCandidates(ctx, hostID, request, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(media) < 2 {
+		return nil, fmt.Errorf("%w: a watch party needs at least two candidates", ErrInvalidParty)
+	}
+
+	code, err := newPartyCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		title = "What should we watch?"
+	}
+	record := &partyRecord{
+		Party: models.WatchParty{
+			Code:         code,
+			HostID:       hostID,
+			Title:        title,
+			Method:       method,
+			Status:       models.PartyOpen,
+			Candidates:   make([]models.PartyCandidate, len(media)),
+			Participants: []models.PartyParticipant{{UserID: hostID, JoinedAt: now}},
+			CreatedAt:    now,
+			ExpiresAt:    now.Add(partyTTL),
+		},
+		Ballots: make(map[string][]int),
+	}
+	for i, item := range media {
+		record.Party.Candidates[i] = models.PartyCandidate{ID: i + 1, Media: item}
+	}
+	record.recount()
+
+	err = s.change(ctx, func(parties map[string]*partyRecord) error {
+		parties[code] = record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record.snapshot(), nil
+}
+
+// partyCandidates gathers up to limit distinct titles for a new party as
+// slim media cards
+func (s *PartyService) partyCandidates(ctx context.Context, hostID string, request models.WatchPartyCreateRequest, limit int) ([]models.Media, error) {
+	var media []models.Media
+	seen := make(map[mediaRef]bool)
+	add := func(item models.Media) {
+		ref := mediaRef{mediaType: item.MediaType, id: item.ID}
+		if len(media) < limit && !seen[ref] {
+			seen[ref] = true
+			media = append(media, models.NewMediaSnapshot(item).Media(item.ID, item.MediaType))
+		}
+	}
+
+	// Titles listed by the host
+	details := make([]*models.Media, len(request.Candidates))
+	errs := make([]error, len(request.Candidates))
+	runPool(ctx, len(request.Candidates), func(i int) {
+		ref := request.Candidates[i]
+		mediaType := ref.MediaType
+		if mediaType == "" {
+			mediaType = models.MediaTypeMovie
+		}
+		if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+			errs[i] = fmt.Errorf("%w: invalid media type %q", ErrInvalidParty, ref.MediaType)
+			return
+		}
+		details[i], errs[i] = s.watchlistService.getMediaDetails(ctx, mediaType, ref.MediaID)
+	})
+	for i, err := range errs {
+		if errors.Is(err, ErrInvalidParty) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get candidate %d: %w", request.Candidates[i].MediaID, err)
+		}
+		add(*details[i])
+	}
+
+	// To-watch titles of a watchlist
+	if request.WatchlistID > 0 {
+		watchlist, err := s.watchlistService.GetWatchlistByID(ctx, request.WatchlistID)
+		if err != nil || (watchlist.UserID != hostID && !watchlist.IsPublic) {
+			return nil, ErrPickWatchlistNotFound
+		}
+		for _, item := range watchlist.Items {
+			if item.Status == "to_watch" || item.Status == "watching" {
+				card := item.Media
+				card.MediaType = itemMediaType(item)
+				add(card)
+			}
+		}
+	}
+
+	// The picker's shortlist, built from the host's own data and other
+	// viewers' public watchlists
+	if request.Pick != nil {
+		pick := *request.Pick
+		if pick.Limit <= 0 || pick.Limit > limit {
+			pick.Limit = limit
+		}
+		result, err := s.pickService.Pick(ctx, hostID, pick)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range result.Shortlist {
+			add(candidate.Media)
+		}
+	}
+
+	return media, nil
+}
+
+// newPartyCode generates a random invite code
+func newPartyCode() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// GetParty returns a watch party by its invite code
+func (s *PartyService) GetParty(ctx context.Context, code string) (*models.WatchParty, error) {
+	parties, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := getParty(parties, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.snapshot(), nil
+}
+
+// getParty looks up a party that hasn't expired
+func getParty(parties map[string]*partyRecord, code string) (*partyRecord, error) {
+	record, exists := parties[code]
+	if !exists || time.Now().After(record.Party.ExpiresAt) {
+		return nil, ErrPartyNotFound
+	}
+	return record, nil
+}
+
+// JoinParty adds a user to an open watch party
+func (s *PartyService) JoinParty(ctx context.Context, code string, userID string) (*models.WatchParty, error) {
+	return s.update(ctx, code, func(record *partyRecord) error {
+		return record.join(userID)
+	})
+}
+
+// Vote records a participant's ranking of the candidates, replacing any
+// earlier ballot, and joins them to the party if they haven't yet
+func (s *PartyService) Vote(ctx context.Context, code string, userID string, request models.PartyVoteRequest) (*models.WatchParty, error) {
+	return s.update(ctx, code, func(record *partyRecord) error {
+		if record.Party.Status == models.PartyClosed {
+			return ErrPartyClosed
+		}
+
+		// Validate the ballot
+		if len(request.Ranking) == 0 {
+			return fmt.Errorf("%w: ranking must list at least one candidate", ErrInvalidParty)
+		}
+		ranked := make(map[int]bool, len(request.Ranking))
+		for _, candidateID := range request.Ranking {
+			if candidateID < 1 || candidateID > len(record.Party.Candidates) {
+				return fmt.Errorf("%w: unknown candidate %d", ErrInvalidParty, candidateID)
+			}
+			if ranked[candidateID] {
+				return fmt.Errorf("%w: candidate %d is ranked twice", ErrInvalidParty, candidateID)
+			}
+			ranked[candidateID] = true
+		}
+
+		if err := record.join(userID); err != nil {
+			return err
+		}
+		record.Ballots[userID] = append([]int{}, request.Ranking...)
+		for i := range record.Party.Participants {
+			if record.Party.Participants[i].UserID == userID {
+				record.Party.Participants[i].Voted = true
+			}
+		}
+		record.recount()
+		return nil
+	})
+}
+
+// CloseParty ends voting and makes the result final. Only the host can
+// close a party.
+func (s *PartyService) CloseParty(ctx context.Context, code string, userID string) (*models.WatchParty, error) {
+	return s.update(ctx, code, func(record *partyRecord) error {
+		if record.Party.HostID != userID {
+			return ErrPartyForbidden
+		}
+		if record.Party.Status == models.PartyClosed {
+			return nil
+		}
+
+		now := time.Now()
+		record.Party.Status = models.PartyClosed
+		record.Party.ClosedAt = &now
+		record.recount()
+		return nil
+	})
+}
+
+// Subscribe returns a channel that receives the party's current state right
+// away and again after every change on any replica. The channel is closed
+// when the party closes or expires. Listeners that fall behind only get the
+// latest state. The returned function unsubscribes.
+func (s *PartyService) Subscribe(ctx context.Context, code string) (<-chan models.WatchParty, func(), error) {
+	parties, err := s.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	record, err := getParty(parties, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan models.WatchParty, 1)
+	ch <- *record.snapshot()
+	if record.Party.Status == models.PartyClosed {
+		close(ch)
+		return ch, func() {}, nil
+	}
+	if s.subscribers[code] == nil {
+		s.subscribers[code] = make(map[chan models.WatchParty]bool)
+		s.versions[code] = record.Version
+	}
+	// A state older than the other listeners' means the next poll must
+	// send the latest again
+	s.versions[code] = min(s.versions[code], record.Version)
+	s.subscribers[code][ch] = true
+	if !s.watching {
+		s.watching = true
+		go s.watch()
+	}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.subscribers[code][ch] {
+			delete(s.subscribers[code], ch)
+			close(ch)
+			if len(s.subscribers[code]) == 0 {
+				delete(s.subscribers, code)
+				delete(s.versions, code)
+			}
+		}
+	}
+
+	return ch, unsubscribe, nil
+}
+
+// CleanupExpired drops expired parties from the shared store. Their
+// listeners' streams already ended when they expired.
+func (s *PartyService) CleanupExpired(ctx context.Context) error {
+	return s.change(ctx, func(parties map[string]*partyRecord) error {
+		return nil
+	})
+}
+
+// load reads every watch party from the shared store
+func (s *PartyService) load(ctx context.Context) (map[string]*partyRecord, error) {
+	parties := make(map[string]*partyRecord)
+	if err := s.store.Load(ctx, partyState, &parties); err != nil {
+		return nil, fmt.Errorf("failed to load watch parties: %w", err)
+	}
+	return parties, nil
+}
+
+// change applies fn to the stored parties under the shared lock and saves
+// them, dropping expired ones. Nothing is saved if fn fails.
+func (s *PartyService) change(ctx context.Context, fn func(parties map[string]*partyRecord) error) error {
+	release, err := lockState(ctx, s.locker, partyLock)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	parties, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(parties); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for code, record := range parties {
+		if now.After(record.Party.ExpiresAt) {
+			delete(parties, code)
+		}
+	}
+
+	if err := s.store.Save(ctx, partyState, parties); err != nil {
+		return fmt.Errorf("failed to save watch parties: %w", err)
+	}
+	return nil
+}
+
+// update changes one party that hasn't expired, then sends its new state to
+// this replica's listeners; other replicas' listeners get it on their next
+// poll
+func (s *PartyService) update(ctx context.Context, code string, fn func(record *partyRecord) error) (*models.WatchParty, error) {
+	var updated *partyRecord
+	err := s.change(ctx, func(parties map[string]*partyRecord) error {
+		record, err := getParty(parties, code)
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+		record.Version++
+		updated = record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.broadcast(code, updated)
+	s.mu.Unlock()
+
+	return updated.snapshot(), nil
+}
+
+// watch polls the shared store while this replica has listeners, passing on
+// changes made elsewhere and ending streams of parties that closed or expired
+func (s *PartyService) watch() {
+	ticker := time.NewTicker(partyPoll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		listening := len(s.subscribers) > 0
+		s.mu.Unlock()
+		if !listening {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), partyPoll)
+		parties, err := s.load(ctx)
+		cancel()
+		if err != nil {
+			// Try again on the next poll
+			continue
+		}
+
+		s.mu.Lock()
+		now := time.Now()
+		for code := range s.subscribers {
+			record, exists := parties[code]
+			if !exists || now.After(record.Party.ExpiresAt) {
+				s.end(code)
+				continue
+			}
+			if record.Version > s.versions[code] {
+				s.broadcast(code, record)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// broadcast sends a party's state to this replica's listeners, replacing
+// any state a slow listener hasn't read yet, and ends their streams once the
+// party is closed; callers must hold the lock
+func (s *PartyService) broadcast(code string, record *partyRecord) {
+	if len(s.subscribers[code]) == 0 || record.Version < s.versions[code] {
+		return
+	}
+	s.versions[code] = record.Version
+
+	state := *record.snapshot()
+	for ch := range s.subscribers[code] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- state
+	}
+
+	// Listeners have the final result, so their streams can end
+	if record.Party.Status == models.PartyClosed {
+		s.end(code)
+	}
+}
+
+// end closes a party's listeners on this replica; callers must hold the lock
+func (s *PartyService) end(code string) {
+	for ch := range s.subscribers[code] {
+		close(ch)
+	}
+	delete(s.subscribers, code)
+	delete(s.versions, code)
+}
+
+// join adds a participant unless they already joined
+func (p *partyRecord) join(userID string) error {
+	for _, participant := range p.Party.Participants {
+		if participant.UserID == userID {
+			return nil
+		}
+	}
+	if p.Party.Status == models.PartyClosed {
+		return ErrPartyClosed
+	}
+	if len(p.Party.Participants) >= maxPartyParticipants {
+		return fmt.Errorf("%w: a watch party can have at most %d participants", ErrInvalidParty, maxPartyParticipants)
+	}
+
+	p.Party.Participants = append(p.Party.Participants, models.PartyParticipant{UserID: userID, JoinedAt: time.Now()})
+	return nil
+}
+
+// recount tallies the ballots cast so far
+func (p *partyRecord) recount() {
+	candidates := make([]int, len(p.Party.Candidates))
+	for i, candidate := range p.Party.Candidates {
+		candidates[i] = candidate.ID
+	}
+
+	// Count ballots in the order participants joined
+	var ballots [][]int
+	for _, participant := range p.Party.Participants {
+		if ballot, voted := p.Ballots[participant.UserID]; voted {
+			ballots = append(ballots, ballot)
+		}
+	}
+
+	p.Party.Result = tallyParty(p.Party.Method, candidates, ballots)
+	p.Party.Result.Final = p.Party.Status == models.PartyClosed
+}
+
+// snapshot copies the party without its ballots
+func (p *partyRecord) snapshot() *models.WatchParty {
+	party := p.Party
+	party.Candidates = append([]models.PartyCandidate{}, p.Party.Candidates...)
+	party.Participants = append([]models.PartyParticipant{}, p.Party.Participants...)
+	return &party
+}
+
+// Close ends every listener's stream on this replica so the server can shut
+// down without waiting on them
+func (s *PartyService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for code := range s.subscribers {
+		s.end(code)
+	}
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
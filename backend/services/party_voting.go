@@ -0,0 +1,181 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// tallyParty counts a watch party's ballots with its voting method. Each
+// ballot ranks candidate IDs, favorite first, and may leave candidates out.
+func tallyParty(method string, candidates []int, ballots [][]int) models.PartyResult {
+	result := models.PartyResult{
+		Method:    method,
+		Ballots:   len(ballots),
+		Standings: []models.PartyStanding{},
+	}
+	if len(ballots) == 0 {
+		return result
+	}
+
+	if method == models.VotingBorda {
+		result.Standings = bordaStandings(candidates, ballots)
+	} else {
+		result.Standings, result.Rounds = instantRunoff(candidates, ballots)
+	}
+	if len(result.Standings) > 0 {
+		winner := result.Standings[0].CandidateID
+		result.Winner = &winner
+	}
+
+	return result
+}
+
+// bordaPoints gives each candidate one point for every candidate among n it
+// is ranked above: n-1 for a first choice down to 0 for the last. Unranked
+// candidates get no points.
+func bordaPoints(candidates []int, ballots [][]int) map[int]float64 {
+	points := make(map[int]float64, len(candidates))
+	for _, ballot := range ballots {
+		for rank, candidateID := range ballot {
+			points[candidateID] += float64(len(candidates) - 1 - rank)
+		}
+	}
+	return points
+}
+
+// firstChoices counts the ballots ranking each candidate first
+func firstChoices(ballots [][]int) map[int]int {
+	counts := make(map[int]int)
+	for _, ballot := range ballots {
+		if len(ballot) > 0 {
+			counts[ballot[0]]++
+		}
+	}
+	return counts
+}
+
+// bordaStandings ranks candidates by Borda points. Ties go to the candidate
+// more ballots ranked first, then to the one listed first.
+func bordaStandings(candidates []int, ballots [][]int) []models.PartyStanding {
+	points := bordaPoints(candidates, ballots)
+	first := firstChoices(ballots)
+
+	standings := make([]models.PartyStanding, len(candidates))
+	for i, candidateID := range candidates {
+		standings[i] = models.PartyStanding{
+			CandidateID:  candidateID,
+			Points:       points[candidateID],
+			FirstChoices: first[candidateID],
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		return standings[i].FirstChoices > standings[j].FirstChoices
+	})
+
+	return standings
+}
+
+// instantRunoff counts ballots in rounds, each going to its highest ranked
+// remaining candidate. A candidate with a majority of the counted ballots
+// wins; otherwise the one with the fewest votes is eliminated and its
+// ballots move on. Ties for elimination knock out the candidate with fewer
+// Borda points, then the one listed last. Standings list the winner first
+// and the rest in reverse order of elimination.
+func instantRunoff(candidates []int, ballots [][]int) ([]models.PartyStanding, []models.PartyRound) {
+	points := bordaPoints(candidates, ballots)
+	first := firstChoices(ballots)
+	position := make(map[int]int, len(candidates))
+	for i, candidateID := range candidates {
+		position[candidateID] = i
+	}
+
+	remaining := make(map[int]bool, len(candidates))
+	for _, candidateID := range candidates {
+		remaining[candidateID] = true
+	}
+
+	var rounds []models.PartyRound
+	lastVotes := make(map[int]int, len(candidates))
+	var eliminated []int
+	for len(remaining) > 0 {
+		// Count each ballot for its highest ranked remaining candidate
+		votes := make(map[int]int, len(remaining))
+		counted, exhausted := 0, 0
+		for _, ballot := range ballots {
+			found := false
+			for _, candidateID := range ballot {
+				if remaining[candidateID] {
+					votes[candidateID]++
+					counted++
+					found = true
+					break
+				}
+			}
+			if !found {
+				exhausted++
+			}
+		}
+
+		round := models.PartyRound{Exhausted: exhausted}
+		var order []int
+		for _, candidateID := range candidates {
+			if remaining[candidateID] {
+				order = append(order, candidateID)
+				lastVotes[candidateID] = votes[candidateID]
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return votes[order[i]] > votes[order[j]]
+		})
+		for _, candidateID := range order {
+			round.Tallies = append(round.Tallies, models.PartyTally{CandidateID: candidateID, Votes: votes[candidateID]})
+		}
+
+		// A majority or the last candidate standing wins
+		if len(order) == 1 || votes[order[0]]*2 > counted {
+			rounds = append(rounds, round)
+			break
+		}
+
+		// Otherwise the weakest candidate is eliminated
+		weakest := order[len(order)-1]
+		for _, candidateID := range order {
+			if votes[candidateID] != votes[weakest] {
+				continue
+			}
+			if points[candidateID] < points[weakest] ||
+				(points[candidateID] == points[weakest] && position[candidateID] > position[weakest]) {
+				weakest = candidateID
+			}
+		}
+		delete(remaining, weakest)
+		eliminated = append(eliminated, weakest)
+		round.Eliminated = &weakest
+		rounds = append(rounds, round)
+	}
+
+	// Winner and runners-up of the final round, then the eliminated
+	standings := make([]models.PartyStanding, 0, len(candidates))
+	finalRound := rounds[len(rounds)-1]
+	for _, tally := range finalRound.Tallies {
+		standings = append(standings, models.PartyStanding{
+			CandidateID:  tally.CandidateID,
+			Points:       float64(tally.Votes),
+			FirstChoices: first[tally.CandidateID],
+		})
+	}
+	for i := len(eliminated) - 1; i >= 0; i-- {
+		candidateID := eliminated[i]
+		standings = append(standings, models.PartyStanding{
+			CandidateID:  candidateID,
+			Points:       float64(lastVotes[candidateID]),
+			FirstChoices: first[candidateID],
+		})
+	}
+
+	return standings, rounds
+}
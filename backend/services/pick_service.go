@@ -0,0 +1,496 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// Pick tuning
+const (
+	maxPickViewers      = 10
+	pickRecommendations = 20  // recommendations fetched per viewer
+	pickLeastMisery     = 0.3 // share of the group's interest set by its least interested viewer
+	pickRatingWeight    = 0.1 // share of the score set by the TMDB vote average
+)
+
+var (
+	// ErrInvalidPick is returned when a pick request's constraints can't be
+	// used
+	ErrInvalidPick = errors.New("invalid pick request")
+	// ErrPickWatchlistNotFound is returned when the watchlist to pick from
+	// doesn't exist or is neither public nor the requester's
+	ErrPickWatchlistNotFound = errors.New("watchlist not found")
+)
+
+// pickMoods maps each mood to the movie and TV genres that suit it
+var pickMoods = map[string][]int{
+	"funny":        {35},
+	"scary":        {27, 9648},
+	"romantic":     {10749},
+	"exciting":     {28, 12, 53, 10752, 10759},
+	"thoughtful":   {18, 36, 99},
+	"mind-bending": {14, 878, 9648, 10765},
+	"dark":         {27, 53, 80},
+	"light":        {16, 35, 10402, 10751},
+	"family":       {16, 10751, 10762},
+}
+
+// PickMoods returns the moods a pick can ask for or avoid, sorted
+func PickMoods() []string {
+	moods := make([]string, 0, len(pickMoods))
+	for mood := range pickMoods {
+		moods = append(moods, mood)
+	}
+	sort.Strings(moods)
+	return moods
+}
+
+// pickCandidate is a title the group might watch tonight
+type pickCandidate struct {
+	media          models.Media
+	interest       map[string]float64 // viewer -> interest between 0 and 1
+	watchlistedBy  []string
+	recommendedFor []string
+	reasons        []string // why it was recommended to each viewer
+	providers      []models.Provider
+}
+
+// pickPool collects candidates without duplicates
+type pickPool struct {
+	exclude    map[mediaRef]bool
+	byRef      map[mediaRef]*pickCandidate
+	candidates []*pickCandidate
+}
+
+// add returns the candidate for a title, creating it unless the title is
+// excluded
+func (p *pickPool) add(media models.Media) *pickCandidate {
+	ref := mediaRef{mediaType: media.MediaType, id: media.ID}
+	if ref.mediaType == "" {
+		ref.mediaType = models.MediaTypeMovie
+	}
+	if p.exclude[ref] || media.Adult {
+		return nil
+	}
+	if candidate, exists := p.byRef[ref]; exists {
+		return candidate
+	}
+
+	media.MediaType = ref.mediaType
+	candidate := &pickCandidate{media: media, interest: make(map[string]float64)}
+	p.byRef[ref] = candidate
+	p.candidates = append(p.candidates, candidate)
+	return candidate
+}
+
+// PickService picks something for one or more people to watch tonight from
+// their watchlists and recommendations
+type PickService struct {
+	tmdbService      *TMDBService
+	watchlistService *WatchlistService
+	settingsService  *SettingsService
+}
+
+// NewPickService creates a new pick service instance
+func NewPickService(tmdbService *TMDBService, watchlistService *WatchlistService, settingsService *SettingsService) *PickService {
+	return &PickService{
+		tmdbService:      tmdbService,
+		watchlistService: watchlistService,
+		settingsService:  settingsService,
+	}
+}
+
+// Pick ranks the titles that fit a request and draws one of them at random,
+// weighted by score. Candidates are what the viewers have saved to watch and
+// what is recommended to the requester, or only the items of the requested
+// watchlist. Other viewers only take part through their public watchlists;
+// those without one are skipped. Titles any viewer has completed or dropped,
+// skipped viewers included, or the requester waved off in recommendation
+// feedback, are never picked.
+// A title scores by how interested the viewers are on average and how
+// interested the least keen of them is, so that one person's favorite
+// doesn't win over everyone else.
+func (s *PickService) Pick(ctx context.Context, userID string, request models.PickRequest) (*models.PickResult, error) {
+	// Validate constraints
+	viewers := pickViewers(userID, request.Viewers)
+	if len(viewers) > maxPickViewers {
+		return nil, fmt.Errorf("%w: at most %d viewers", ErrInvalidPick, maxPickViewers)
+	}
+	if request.MediaType != "" && request.MediaType != models.MediaTypeMovie && request.MediaType != models.MediaTypeTV {
+		return nil, fmt.Errorf("%w: invalid media type %q", ErrInvalidPick, request.MediaType)
+	}
+	include, err := pickGenres(request.Moods, request.IncludeGenres)
+	if err != nil {
+		return nil, err
+	}
+	avoid, err := pickGenres(request.AvoidMoods, request.ExcludeGenres)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather candidates
+	pool, viewers, skipped, err := s.pickCandidates(ctx, viewers, request.WatchlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch titles whose cards lack what the constraints check
+	runPool(ctx, len(pool.candidates), func(i int) {
+		candidate := pool.candidates[i]
+		missingRuntime := request.MaxRuntime > 0 && candidate.media.Runtime == 0
+		missingGenres := len(include)+len(avoid) > 0 && len(mediaGenreIDs(candidate.media)) == 0
+		if !missingRuntime && !missingGenres {
+			return
+		}
+		if details, err := s.watchlistService.getMediaDetails(ctx, candidate.media.MediaType, candidate.media.ID); err == nil {
+			candidate.media = *details
+		}
+	})
+
+	// Apply constraints
+	var matching []*pickCandidate
+	for _, candidate := range pool.candidates {
+		if pickMatches(candidate.media, request, include, avoid) {
+			matching = append(matching, candidate)
+		}
+	}
+	if request.MyServicesOnly {
+		matching, err = s.onServices(ctx, viewers[0], matching)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Score and rank
+	shortlist := make([]models.PickCandidate, len(matching))
+	for i, candidate := range matching {
+		shortlist[i] = scorePickCandidate(candidate, viewers)
+	}
+	sort.SliceStable(shortlist, func(i, j int) bool {
+		return shortlist[i].Score > shortlist[j].Score
+	})
+	if len(shortlist) > request.Limit {
+		shortlist = shortlist[:request.Limit]
+	}
+
+	return &models.PickResult{
+		Pick:           drawPick(shortlist),
+		Shortlist:      shortlist,
+		Viewers:        viewers,
+		SkippedViewers: skipped,
+		Considered:     len(pool.candidates),
+	}, nil
+}
+
+// pickViewers returns the requester followed by the other viewers, without
+// duplicates
+func pickViewers(userID string, others []string) []string {
+	viewers := []string{userID}
+	seen := map[string]bool{userID: true}
+	for _, viewer := range others {
+		viewer = strings.TrimSpace(viewer)
+		if viewer != "" && !seen[viewer] {
+			seen[viewer] = true
+			viewers = append(viewers, viewer)
+		}
+	}
+	return viewers
+}
+
+// pickGenres resolves moods to genres and adds them to the given genre IDs
+func pickGenres(moods []string, genreIDs []int) (map[int]bool, error) {
+	genres := make(map[int]bool, len(genreIDs))
+	for _, genreID := range genreIDs {
+		genres[genreID] = true
+	}
+	for _, mood := range moods {
+		moodGenres, exists := pickMoods[strings.ToLower(strings.TrimSpace(mood))]
+		if !exists {
+			return nil, fmt.Errorf("%w: unknown mood %q, must be one of %s", ErrInvalidPick, mood, strings.Join(PickMoods(), ", "))
+		}
+		for _, genreID := range moodGenres {
+			genres[genreID] = true
+		}
+	}
+	return genres, nil
+}
+
+// pickCandidates gathers the titles the viewers might watch and how
+// interested each of them is, and returns the viewers taking part and those
+// skipped. Saving a title counts as full interest and a recommendation as
+// much as its score. Only the requester's recommendations and feedback are
+// read; other viewers take part only with a public watchlist. A private
+// watchlist is only used to leave out titles its owner has seen, so nothing
+// in it is reported.
+func (s *PickService) pickCandidates(ctx context.Context, viewers []string, watchlistID int) (*pickPool, []string, []string, error) {
+	// Load every viewer's watchlist and the requester's recommendations
+	loaded := make([]*models.Watchlist, len(viewers))
+	watchlists := make([]*models.Watchlist, len(viewers))
+	var recommendations []models.WatchlistRecommendation
+	runPool(ctx, len(viewers), func(i int) {
+		watchlist, err := s.watchlistService.GetWatchlist(ctx, viewers[i])
+		if err != nil {
+			return
+		}
+		loaded[i] = watchlist
+		if i > 0 && !watchlist.IsPublic {
+			return
+		}
+		watchlists[i] = watchlist
+		if i == 0 {
+			recommendations, _, _ = s.watchlistService.getRecommendations(ctx, viewers[i], pickRecommendations)
+		}
+	})
+
+	// Other viewers without a public watchlist are left out
+	taking := []string{viewers[0]}
+	skipped := []string{}
+	for i, viewer := range viewers[1:] {
+		if watchlists[i+1] != nil {
+			taking = append(taking, viewer)
+		} else {
+			skipped = append(skipped, viewer)
+		}
+	}
+
+	// Titles any viewer has seen or the requester doesn't want are never picked
+	pool := &pickPool{
+		exclude: make(map[mediaRef]bool),
+		byRef:   make(map[mediaRef]*pickCandidate),
+	}
+	for _, watchlist := range loaded {
+		if watchlist == nil {
+			continue
+		}
+		for _, item := range watchlist.Items {
+			if item.Status == "completed" || item.Status == "dropped" {
+				pool.exclude[itemRef(item)] = true
+			}
+		}
+	}
+	feedback, _ := s.watchlistService.recommendationFeedback(viewers[0])
+	for _, entry := range feedback {
+		if entry.Feedback != models.FeedbackMoreLikeThis {
+			pool.exclude[mediaRef{mediaType: entry.MediaType, id: entry.MediaID}] = true
+		}
+	}
+
+	// Picking from one watchlist limits the candidates to its items
+	restricted := watchlistID > 0
+	if restricted {
+		watchlist, err := s.watchlistService.GetWatchlistByID(ctx, watchlistID)
+		if err != nil {
+			return nil, nil, nil, ErrPickWatchlistNotFound
+		}
+		if !watchlist.IsPublic && watchlist.UserID != viewers[0] {
+			return nil, nil, nil, ErrPickWatchlistNotFound
+		}
+		for _, item := range watchlist.Items {
+			if item.Status == "to_watch" || item.Status == "watching" {
+				media := item.Media
+				media.MediaType = itemMediaType(item)
+				pool.add(media)
+			}
+		}
+	}
+
+	for i, viewer := range viewers {
+		if watchlists[i] == nil {
+			continue
+		}
+		for _, item := range watchlists[i].Items {
+			if item.Status != "to_watch" && item.Status != "watching" {
+				continue
+			}
+			media := item.Media
+			media.MediaType = itemMediaType(item)
+			candidate := pool.find(media, restricted)
+			if candidate == nil {
+				continue
+			}
+			candidate.interest[viewer] = 1
+			candidate.watchlistedBy = append(candidate.watchlistedBy, viewer)
+		}
+	}
+
+	for _, recommendation := range recommendations {
+		candidate := pool.find(recommendation.Media, restricted)
+		if candidate == nil {
+			continue
+		}
+		candidate.interest[viewers[0]] = max(candidate.interest[viewers[0]], min(max(recommendation.Score, 0), 1))
+		candidate.recommendedFor = append(candidate.recommendedFor, viewers[0])
+		if recommendation.Reason != "" {
+			candidate.reasons = append(candidate.reasons, fmt.Sprintf("Recommended for %s: %s", viewers[0], recommendation.Reason))
+		}
+	}
+
+	return pool, taking, skipped, nil
+}
+
+// find returns the candidate for a title, adding it unless the pool is
+// restricted to the titles already in it
+func (p *pickPool) find(media models.Media, restricted bool) *pickCandidate {
+	if !restricted {
+		return p.add(media)
+	}
+	ref := mediaRef{mediaType: media.MediaType, id: media.ID}
+	if ref.mediaType == "" {
+		ref.mediaType = models.MediaTypeMovie
+	}
+	return p.byRef[ref]
+}
+
+// pickMatches checks a title against the request's constraints. Titles
+// whose runtime is unknown never fit a maximum runtime.
+func pickMatches(media models.Media, request models.PickRequest, include map[int]bool, avoid map[int]bool) bool {
+	if request.MediaType != "" && media.MediaType != request.MediaType {
+		return false
+	}
+	if request.MaxRuntime > 0 && (media.Runtime == 0 || media.Runtime > request.MaxRuntime) {
+		return false
+	}
+	if request.MinRating > 0 && media.VoteAverage < request.MinRating {
+		return false
+	}
+
+	included := len(include) == 0
+	for _, genreID := range mediaGenreIDs(media) {
+		if avoid[genreID] {
+			return false
+		}
+		if include[genreID] {
+			included = true
+		}
+	}
+
+	return included
+}
+
+// onServices keeps the titles that stream on the requester's subscriptions
+// in their region, along with those services. Other viewers' settings are
+// private, so their services don't count. Titles whose availability can't be
+// fetched are left out.
+func (s *PickService) onServices(ctx context.Context, userID string, candidates []*pickCandidate) ([]*pickCandidate, error) {
+	settings := s.settingsService.GetSettings(ctx, userID)
+	region := settings.Region
+	subscribed := make(map[int]bool)
+	for _, providerID := range settings.ProviderIDs {
+		subscribed[providerID] = true
+	}
+	if len(subscribed) == 0 {
+		return nil, fmt.Errorf("%w: save your streaming services first", ErrInvalidPick)
+	}
+
+	available := make([]bool, len(candidates))
+	var mu sync.Mutex
+	runPool(ctx, len(candidates), func(i int) {
+		candidate := candidates[i]
+		providers, err := s.tmdbService.GetWatchProviders(ctx, candidate.media.MediaType, candidate.media.ID)
+		if err != nil {
+			return
+		}
+
+		// Only subscription services count
+		regional := providers.Results[region]
+		var matched []models.Provider
+		seen := make(map[int]bool)
+		for _, list := range [][]models.Provider{regional.Flatrate, regional.Free, regional.Ads} {
+			for _, provider := range list {
+				if subscribed[provider.ID] && !seen[provider.ID] {
+					seen[provider.ID] = true
+					matched = append(matched, provider)
+				}
+			}
+		}
+		sortProviders(matched)
+
+		mu.Lock()
+		candidate.providers = matched
+		available[i] = len(matched) > 0
+		mu.Unlock()
+	})
+
+	var result []*pickCandidate
+	for i, candidate := range candidates {
+		if available[i] {
+			result = append(result, candidate)
+		}
+	}
+
+	return result, nil
+}
+
+// scorePickCandidate blends the group's interest in a title with its TMDB
+// rating
+func scorePickCandidate(candidate *pickCandidate, viewers []string) models.PickCandidate {
+	total, least := 0.0, 1.0
+	for _, viewer := range viewers {
+		interest := candidate.interest[viewer]
+		total += interest
+		least = min(least, interest)
+	}
+	groupInterest := (1-pickLeastMisery)*total/float64(len(viewers)) + pickLeastMisery*least
+	score := (1-pickRatingWeight)*groupInterest + pickRatingWeight*candidate.media.VoteAverage/10
+
+	reasons := []string{}
+	if len(viewers) > 1 && least > 0 {
+		reasons = append(reasons, "Everyone is interested")
+	}
+	if len(candidate.watchlistedBy) > 0 {
+		reasons = append(reasons, "On the watchlist of "+strings.Join(candidate.watchlistedBy, ", "))
+	}
+	reasons = append(reasons, candidate.reasons...)
+	if len(candidate.providers) > 0 {
+		names := make([]string, len(candidate.providers))
+		for i, provider := range candidate.providers {
+			names[i] = provider.Name
+		}
+		reasons = append(reasons, "Streaming on "+strings.Join(names, ", "))
+	}
+
+	return models.PickCandidate{
+		Media:          candidate.media,
+		Score:          score,
+		Reasons:        reasons,
+		WatchlistedBy:  append([]string{}, candidate.watchlistedBy...),
+		RecommendedFor: append([]string{}, candidate.recommendedFor...),
+		Providers:      candidate.providers,
+	}
+}
+
+// drawPick draws a title from the shortlist at random, favoring higher
+// scores by weighting each title by its score squared
+func drawPick(shortlist []models.PickCandidate) *models.PickCandidate {
+	if len(shortlist) == 0 {
+		return nil
+	}
+
+	total := 0.0
+	for _, candidate := range shortlist {
+		total += candidate.Score * candidate.Score
+	}
+	if total == 0 {
+		pick := shortlist[rand.IntN(len(shortlist))]
+		return &pick
+	}
+
+	target := rand.Float64() * total
+	for _, candidate := range shortlist {
+		target -= candidate.Score * candidate.Score
+		if target < 0 {
+			pick := candidate
+			return &pick
+		}
+	}
+
+	pick := shortlist[len(shortlist)-1]
+	return &pick
+}
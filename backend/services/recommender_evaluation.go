@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// EvaluationOptions controls how watchlists are split and results are cut off
+// in an offline evaluation
+type EvaluationOptions struct {
+	K            int       // recommendations scored per user
+	HoldoutRatio float64   // newest share of each user's items held out, when Cutoff is zero
+	Cutoff       time.Time // items added after it are held out for every user
+	MinItems     int       // users with fewer items are only used for training
+}
+
+// evaluationUser is a user whose newer liked titles are held out
+type evaluationUser struct {
+	userID   string
+	history  []models.WatchlistItem
+	relevant map[mediaRef]bool
+}
+
+// EvaluateRecommenders splits every watchlist by when items were added,
+// trains each strategy on the older items and scores its recommendations
+// against the newer titles users liked. Held out items count as liked unless
+// they were rated 5/10 or lower or dropped.
+func EvaluateRecommenders(ctx context.Context, watchlists map[string][]models.WatchlistItem, strategies []RecommenderStrategy, options EvaluationOptions) ([]models.RecommenderEvaluation, error) {
+	if options.K <= 0 {
+		return nil, fmt.Errorf("k must be positive")
+	}
+
+	train, users := splitWatchlists(watchlists, options)
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no users with held out titles to evaluate")
+	}
+	_, savedBy := trainingCatalog(train)
+
+	evaluations := make([]models.RecommenderEvaluation, 0, len(strategies))
+	for _, strategy := range strategies {
+		started := time.Now()
+		if err := strategy.Train(ctx, train); err != nil {
+			return nil, fmt.Errorf("failed to train %s: %w", strategy.Name(), err)
+		}
+
+		evaluation := models.RecommenderEvaluation{
+			Strategy: strategy.Name(),
+			K:        options.K,
+			Users:    len(users),
+		}
+		recommended := make(map[mediaRef]bool)
+		noveltySum, recommendations := 0.0, 0
+		for _, user := range users {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			results, err := strategy.Recommend(ctx, user.userID, user.history, options.K)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recommend with %s: %w", strategy.Name(), err)
+			}
+			if len(results) > options.K {
+				results = results[:options.K]
+			}
+
+			refs := make([]mediaRef, len(results))
+			for i, media := range results {
+				refs[i] = mediaRef{mediaType: media.MediaType, id: media.ID}
+				if refs[i].mediaType == "" {
+					refs[i].mediaType = models.MediaTypeMovie
+				}
+				recommended[refs[i]] = true
+				noveltySum += -math.Log2(math.Max(float64(savedBy[refs[i]]), 1) / float64(len(train)))
+				recommendations++
+			}
+
+			precision, recall, ndcg := rankingMetrics(refs, user.relevant, options.K)
+			evaluation.Precision += precision
+			evaluation.Recall += recall
+			evaluation.NDCG += ndcg
+		}
+
+		evaluation.Precision /= float64(len(users))
+		evaluation.Recall /= float64(len(users))
+		evaluation.NDCG /= float64(len(users))
+		if len(savedBy) > 0 {
+			evaluation.Coverage = float64(len(recommended)) / float64(len(savedBy))
+		}
+		if recommendations > 0 {
+			evaluation.Novelty = noveltySum / float64(recommendations)
+		}
+		evaluation.Duration = time.Since(started).Round(time.Millisecond).String()
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations, nil
+}
+
+// splitWatchlists holds out each user's newest items, or every item added
+// after the cutoff, and returns the training watchlists along with the users
+// who have liked titles held out
+func splitWatchlists(watchlists map[string][]models.WatchlistItem, options EvaluationOptions) (map[string][]models.WatchlistItem, []evaluationUser) {
+	userIDs := make([]string, 0, len(watchlists))
+	for userID := range watchlists {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	train := make(map[string][]models.WatchlistItem, len(watchlists))
+	var users []evaluationUser
+	for _, userID := range userIDs {
+		items := append([]models.WatchlistItem{}, watchlists[userID]...)
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].AddedAt.Before(items[j].AddedAt)
+		})
+
+		if len(items) < options.MinItems {
+			train[userID] = items
+			continue
+		}
+
+		split := len(items)
+		if !options.Cutoff.IsZero() {
+			split = sort.Search(len(items), func(i int) bool {
+				return items[i].AddedAt.After(options.Cutoff)
+			})
+		} else if held := int(math.Round(float64(len(items)) * options.HoldoutRatio)); held > 0 {
+			split = len(items) - held
+		}
+		if split < 1 {
+			split = 1
+		}
+
+		train[userID] = items[:split]
+		relevant := make(map[mediaRef]bool)
+		for _, item := range items[split:] {
+			if itemWeight(item) > 0 {
+				relevant[itemRef(item)] = true
+			}
+		}
+		if len(relevant) > 0 {
+			users = append(users, evaluationUser{userID: userID, history: items[:split], relevant: relevant})
+		}
+	}
+
+	return train, users
+}
+
+// rankingMetrics returns precision, recall and NDCG at k of a ranked list
+// against the relevant titles, with binary relevance
+func rankingMetrics(ranked []mediaRef, relevant map[mediaRef]bool, k int) (float64, float64, float64) {
+	hits := 0
+	dcg := 0.0
+	for i, ref := range ranked {
+		if i == k {
+			break
+		}
+		if relevant[ref] {
+			hits++
+			dcg += 1 / math.Log2(float64(i)+2)
+		}
+	}
+
+	idcg := 0.0
+	for i := 0; i < len(relevant) && i < k; i++ {
+		idcg += 1 / math.Log2(float64(i)+2)
+	}
+
+	precision := float64(hits) / float64(k)
+	recall := float64(hits) / float64(len(relevant))
+	ndcg := 0.0
+	if idcg > 0 {
+		ndcg = dcg / idcg
+	}
+	return precision, recall, ndcg
+}
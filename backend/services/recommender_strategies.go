@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// RecommenderStrategy is a recommendation algorithm that can be trained on a
+// snapshot of watchlists and compared with others offline
+type RecommenderStrategy interface {
+	// Name identifies the strategy in evaluation reports
+	Name() string
+	// Train learns from every user's watchlist items
+	Train(ctx context.Context, watchlists map[string][]models.WatchlistItem) error
+	// Recommend returns up to limit titles for a user, best first, leaving
+	// out the titles in their history
+	Recommend(ctx context.Context, userID string, history []models.WatchlistItem, limit int) ([]models.Media, error)
+}
+
+// Registered recommender strategies by name. Each evaluation gets fresh
+// instances from the factories.
+var (
+	strategiesMu          sync.RWMutex
+	recommenderStrategies = map[string]func() RecommenderStrategy{
+		"popularity": func() RecommenderStrategy { return &popularityRecommender{} },
+		"content": func() RecommenderStrategy {
+			weights := DefaultRecommendationWeights()
+			weights.Collaborative = 0
+			return NewWeightedRecommender("content", weights)
+		},
+		"collaborative": func() RecommenderStrategy { return &collaborativeRecommender{} },
+		"hybrid": func() RecommenderStrategy {
+			return NewWeightedRecommender("hybrid", DefaultRecommendationWeights())
+		},
+	}
+)
+
+// RegisterRecommenderStrategy makes a strategy available to evaluations,
+// replacing any strategy registered under the same name
+func RegisterRecommenderStrategy(name string, factory func() RecommenderStrategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	recommenderStrategies[name] = factory
+}
+
+// NewRecommenderStrategy creates a registered strategy by name
+func NewRecommenderStrategy(name string) (RecommenderStrategy, error) {
+	strategiesMu.RLock()
+	factory, exists := recommenderStrategies[name]
+	strategiesMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown recommender strategy %q", name)
+	}
+	return factory(), nil
+}
+
+// RecommenderStrategyNames returns the names of the registered strategies in
+// alphabetical order
+func RecommenderStrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	names := make([]string, 0, len(recommenderStrategies))
+	for name := range recommenderStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// trainingCatalog returns the media card of every title in a snapshot along
+// with how many users saved it
+func trainingCatalog(watchlists map[string][]models.WatchlistItem) (map[mediaRef]models.Media, map[mediaRef]int) {
+	cards := make(map[mediaRef]models.Media)
+	users := make(map[mediaRef]int)
+	for _, items := range watchlists {
+		seen := make(map[mediaRef]bool, len(items))
+		for _, item := range items {
+			ref := itemRef(item)
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			users[ref]++
+			if _, exists := cards[ref]; !exists {
+				card := item.Media
+				card.ID = ref.id
+				card.MediaType = ref.mediaType
+				cards[ref] = card
+			}
+		}
+	}
+	return cards, users
+}
+
+// historyRefs returns the titles in a user's history
+func historyRefs(history []models.WatchlistItem) map[mediaRef]bool {
+	refs := make(map[mediaRef]bool, len(history))
+	for _, item := range history {
+		refs[itemRef(item)] = true
+	}
+	return refs
+}
+
+// popularityRecommender recommends the titles saved by the most users, the
+// baseline every other strategy has to beat
+type popularityRecommender struct {
+	ranked []models.Media
+}
+
+func (r *popularityRecommender) Name() string { return "popularity" }
+
+func (r *popularityRecommender) Train(ctx context.Context, watchlists map[string][]models.WatchlistItem) error {
+	cards, users := trainingCatalog(watchlists)
+
+	refs := make([]mediaRef, 0, len(cards))
+	for ref := range cards {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if users[refs[i]] != users[refs[j]] {
+			return users[refs[i]] > users[refs[j]]
+		}
+		if refs[i].mediaType != refs[j].mediaType {
+			return refs[i].mediaType < refs[j].mediaType
+		}
+		return refs[i].id < refs[j].id
+	})
+
+	r.ranked = make([]models.Media, len(refs))
+	for i, ref := range refs {
+		r.ranked[i] = cards[ref]
+	}
+	return nil
+}
+
+func (r *popularityRecommender) Recommend(ctx context.Context, userID string, history []models.WatchlistItem, limit int) ([]models.Media, error) {
+	exclude := historyRefs(history)
+
+	results := []models.Media{}
+	for _, media := range r.ranked {
+		if len(results) == limit {
+			break
+		}
+		if !exclude[mediaRef{mediaType: media.MediaType, id: media.ID}] {
+			results = append(results, media)
+		}
+	}
+	return results, nil
+}
+
+// collaborativeRecommender recommends by item-item collaborative filtering
+// alone
+type collaborativeRecommender struct {
+	model *itemSimilarityModel
+}
+
+func (r *collaborativeRecommender) Name() string { return "collaborative" }
+
+func (r *collaborativeRecommender) Train(ctx context.Context, watchlists map[string][]models.WatchlistItem) error {
+	model, err := buildSimilarityModel(ctx, watchlists)
+	if err != nil {
+		return err
+	}
+	r.model = model
+	return nil
+}
+
+func (r *collaborativeRecommender) Recommend(ctx context.Context, userID string, history []models.WatchlistItem, limit int) ([]models.Media, error) {
+	predictions := r.model.predict(historyWeights(history))
+
+	exclude := historyRefs(history)
+	for ref := range exclude {
+		delete(predictions, ref)
+	}
+
+	return r.model.candidates(predictions, limit), nil
+}
+
+// weightedRecommender scores every title in the training catalog the way
+// GetRecommendations scores candidates, with its own weights. Offline it only
+// sees the traits stored on watchlist items, not keywords or people.
+type weightedRecommender struct {
+	name    string
+	weights RecommendationWeights
+	catalog []models.Media
+	model   *itemSimilarityModel
+}
+
+// NewWeightedRecommender creates a strategy that ranks titles against the
+// user's taste profile with the given weights, blending in collaborative
+// filtering when its weight is above zero
+func NewWeightedRecommender(name string, weights RecommendationWeights) RecommenderStrategy {
+	return &weightedRecommender{name: name, weights: weights}
+}
+
+func (r *weightedRecommender) Name() string { return r.name }
+
+// Weights returns the weights the strategy ranks with, which lets
+// GetRecommendations serve it
+func (r *weightedRecommender) Weights() RecommendationWeights { return r.weights }
+
+func (r *weightedRecommender) Train(ctx context.Context, watchlists map[string][]models.WatchlistItem) error {
+	cards, _ := trainingCatalog(watchlists)
+	r.catalog = make([]models.Media, 0, len(cards))
+	for _, card := range cards {
+		r.catalog = append(r.catalog, card)
+	}
+	sort.Slice(r.catalog, func(i, j int) bool {
+		if r.catalog[i].MediaType != r.catalog[j].MediaType {
+			return r.catalog[i].MediaType < r.catalog[j].MediaType
+		}
+		return r.catalog[i].ID < r.catalog[j].ID
+	})
+
+	r.model = nil
+	if r.weights.Collaborative > 0 {
+		model, err := buildSimilarityModel(ctx, watchlists)
+		if err != nil {
+			return err
+		}
+		r.model = model
+	}
+	return nil
+}
+
+func (r *weightedRecommender) Recommend(ctx context.Context, userID string, history []models.WatchlistItem, limit int) ([]models.Media, error) {
+	weighted := historyWeights(history)
+	profile := buildTasteProfile(weighted, nil, nil)
+	var predictions map[mediaRef]collaborativePrediction
+	if r.model != nil {
+		predictions = r.model.predict(weighted)
+	}
+
+	exclude := historyRefs(history)
+	type scored struct {
+		media models.Media
+		score float64
+	}
+	var results []scored
+	for _, media := range r.catalog {
+		ref := mediaRef{mediaType: media.MediaType, id: media.ID}
+		if exclude[ref] {
+			continue
+		}
+		candidate := &recommendationCandidate{media: media, collaborative: predictions[ref]}
+		results = append(results, scored{media: media, score: scoreCandidate(candidate, profile, r.weights).Score})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+
+	recommendations := []models.Media{}
+	for i := 0; i < len(results) && i < limit; i++ {
+		recommendations = append(recommendations, results[i].media)
+	}
+	return recommendations, nil
+}
+
+// historyWeights weights a user's history the way GetRecommendations does,
+// strongest opinions first
+func historyWeights(history []models.WatchlistItem) []weightedItem {
+	weighted := make([]weightedItem, len(history))
+	for i, item := range history {
+		weighted[i] = weightedItem{item: item, weight: itemWeight(item)}
+	}
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return math.Abs(weighted[i].weight) > math.Abs(weighted[j].weight)
+	})
+	return weighted
+}
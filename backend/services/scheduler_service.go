@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// maxJobHistory caps how many runs are kept per job
+const maxJobHistory = 50
+
+// Scheduler errors
+var (
+	ErrJobNotFound      = errors.New("job not found")
+	ErrJobRunning       = errors.New("job is already running")
+	ErrSchedulerStopped = errors.New("scheduler is not running")
+)
+
+// JobFunc is the work done by a background job
+type JobFunc func(ctx context.Context) error
+
+// JobOptions controls how a job is run
+type JobOptions struct {
+	// RunOnStart runs the job as soon as the scheduler starts
+	RunOnStart bool
+	// Exclusive jobs take a lock shared by every replica before running
+	Exclusive bool
+}
+
+// scheduledJob is a registered job and its state
+type scheduledJob struct {
+	name     string
+	spec     string
+	schedule utils.Schedule
+	task     JobFunc
+	options  JobOptions
+
+	running bool
+	nextRun time.Time
+	history []models.JobRun // newest first
+}
+
+// SchedulerService runs background jobs on cron-like schedules. A job never
+// overlaps with itself, and exclusive jobs run on only one replica at a time
+// and only once per scheduled slot.
+type SchedulerService struct {
+	locker  JobLocker
+	lockTTL time.Duration
+	jitter  time.Duration
+	onError func(job string, err error)
+
+	mu     sync.Mutex
+	jobs   map[string]*scheduledJob
+	order  []string
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSchedulerService creates a scheduler. Each scheduled run is delayed by a
+// random amount up to jitter so replicas don't hit TMDB at the same moment.
+// onError is called for every failed run.
+func NewSchedulerService(locker JobLocker, lockTTL time.Duration, jitter time.Duration, onError func(job string, err error)) *SchedulerService {
+	return &SchedulerService{
+		locker:  locker,
+		lockTTL: lockTTL,
+		jitter:  jitter,
+		onError: onError,
+		jobs:    make(map[string]*scheduledJob),
+	}
+}
+
+// Register adds a job that runs on the given schedule. It must be called
+// before Start.
+func (s *SchedulerService) Register(name string, spec string, task JobFunc, options JobOptions) error {
+	schedule, err := utils.ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("failed to register job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+	s.jobs[name] = &scheduledJob{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		task:     task,
+		options:  options,
+	}
+	s.order = append(s.order, name)
+
+	return nil
+}
+
+// Start runs every registered job until Stop is called or ctx is cancelled
+func (s *SchedulerService) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, name := range s.order {
+		job := s.jobs[name]
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(s.ctx, job)
+		}()
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *SchedulerService) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Jobs lists the registered jobs in registration order
+func (s *SchedulerService) Jobs() []models.JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]models.JobInfo, 0, len(s.order))
+	for _, name := range s.order {
+		job := s.jobs[name]
+		info := models.JobInfo{
+			Name:      job.name,
+			Schedule:  job.spec,
+			Exclusive: job.options.Exclusive,
+			Running:   job.running,
+		}
+		if !job.nextRun.IsZero() {
+			nextRun := job.nextRun
+			info.NextRun = &nextRun
+		}
+		if len(job.history) > 0 {
+			lastRun := job.history[0]
+			info.LastRun = &lastRun
+		}
+		jobs = append(jobs, info)
+	}
+
+	return jobs
+}
+
+// History returns a job's most recent runs, newest first
+func (s *SchedulerService) History(name string) ([]models.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return nil, ErrJobNotFound
+	}
+
+	return append([]models.JobRun{}, job.history...), nil
+}
+
+// Trigger starts a job immediately in the background
+func (s *SchedulerService) Trigger(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return ErrJobNotFound
+	}
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return ErrSchedulerStopped
+	}
+	if job.running {
+		return ErrJobRunning
+	}
+	job.running = true
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(s.ctx, job, models.JobTriggerManual, time.Time{})
+	}()
+
+	return nil
+}
+
+// loop runs a job on its schedule until ctx is cancelled
+func (s *SchedulerService) loop(ctx context.Context, job *scheduledJob) {
+	if job.options.RunOnStart {
+		s.run(ctx, job, models.JobTriggerStartup, time.Time{})
+	}
+
+	for {
+		slot := job.schedule.Next(time.Now())
+		if slot.IsZero() {
+			return
+		}
+		next := slot
+		if s.jitter > 0 {
+			next = next.Add(rand.N(s.jitter))
+		}
+
+		s.mu.Lock()
+		job.nextRun = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.run(ctx, job, models.JobTriggerSchedule, slot)
+	}
+}
+
+// run executes a job unless a previous run is still going. slot is the
+// scheduled time the run is for, or zero for startup and manual runs.
+func (s *SchedulerService) run(ctx context.Context, job *scheduledJob, trigger string, slot time.Time) {
+	s.mu.Lock()
+	if job.running {
+		s.mu.Unlock()
+		now := time.Now()
+		s.record(job, models.JobRun{
+			Job:        job.name,
+			Trigger:    trigger,
+			Status:     models.JobStatusSkipped,
+			StartedAt:  now,
+			FinishedAt: now,
+			Error:      "previous run still in progress",
+		})
+		return
+	}
+	job.running = true
+	s.mu.Unlock()
+
+	s.execute(ctx, job, trigger, slot)
+}
+
+// execute runs a job that has already been marked as running
+func (s *SchedulerService) execute(ctx context.Context, job *scheduledJob, trigger string, slot time.Time) {
+	defer func() {
+		s.mu.Lock()
+		job.running = false
+		s.mu.Unlock()
+	}()
+
+	run := models.JobRun{
+		Job:       job.name,
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+	}
+
+	err := s.runTask(ctx, job, slot, &run)
+	run.FinishedAt = time.Now()
+	run.DurationMS = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+	if err != nil {
+		run.Status = models.JobStatusFailed
+		run.Error = err.Error()
+		// Runs cut short by shutdown aren't failures worth reporting
+		if ctx.Err() == nil && s.onError != nil {
+			s.onError(job.name, err)
+		}
+	}
+
+	s.record(job, run)
+}
+
+// runTask takes the job's shared locks if needed and runs its task
+func (s *SchedulerService) runTask(ctx context.Context, job *scheduledJob, slot time.Time, run *models.JobRun) error {
+	if job.options.Exclusive && s.locker != nil {
+		// Claim the slot. Replicas fire up to the jitter apart, so its lock is
+		// left to expire rather than released, or a later replica would run
+		// the same slot again.
+		if !slot.IsZero() {
+			slotLock := fmt.Sprintf("%s@%d", job.name, slot.Unix())
+			_, acquired, err := s.locker.TryLock(ctx, slotLock, max(s.lockTTL, 2*s.jitter))
+			if err != nil {
+				return fmt.Errorf("failed to acquire lock: %w", err)
+			}
+			if !acquired {
+				run.Status = models.JobStatusSkipped
+				run.Error = "already ran on another instance"
+				return nil
+			}
+		}
+
+		release, acquired, err := s.locker.TryLock(ctx, job.name, s.lockTTL)
+		if err != nil {
+			return fmt.Errorf("failed to acquire lock: %w", err)
+		}
+		if !acquired {
+			run.Status = models.JobStatusSkipped
+			run.Error = "running on another instance"
+			return nil
+		}
+		defer release()
+	}
+
+	if err := job.task(ctx); err != nil {
+		return err
+	}
+
+	run.Status = models.JobStatusSuccess
+	return nil
+}
+
+// record adds a run to a job's history
+func (s *SchedulerService) record(job *scheduledJob, run models.JobRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := append([]models.JobRun{run}, job.history...)
+	if len(history) > maxJobHistory {
+		history = history[:maxJobHistory]
+	}
+	job.history = history
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// SearchIndexService maintains a local full-text index of titles fetched from
+// TMDB so the catalog stays searchable when TMDB is unavailable. Once it holds
+// maxDocs titles, the ones seen least recently are dropped.
+type SearchIndexService struct {
+	index   *utils.FullTextIndex
+	path    string
+	maxDocs int
+
+	mu       sync.RWMutex
+	media    map[string]models.Media
+	lastSeen map[string]time.Time
+	dirty    bool
+}
+
+// persistedSearchIndex is the on-disk format of the search index
+type persistedSearchIndex struct {
+	Documents []utils.TextDocument    `json:"documents"`
+	Media     map[string]models.Media `json:"media"`
+	LastSeen  map[string]time.Time    `json:"last_seen,omitempty"`
+}
+
+// NewSearchIndexService creates a new search index service and subscribes it
+// to titles fetched by the TMDB service
+func NewSearchIndexService(tmdbService *TMDBService) *SearchIndexService {
+	s := &SearchIndexService{
+		index:    utils.NewFullTextIndex(),
+		path:     filepath.Join(config.AppConfig.Cache.Dir, "search_index.json"),
+		maxDocs:  config.AppConfig.Cache.SearchIndexMaxDocs,
+		media:    make(map[string]models.Media),
+		lastSeen: make(map[string]time.Time),
+	}
+	tmdbService.AddObserver(s)
+	return s
+}
+
+// Load restores the index persisted by a previous run
+func (s *SearchIndexService) Load() error {
+	var persisted persistedSearchIndex
+	if err := utils.LoadJSONFile(s.path, &persisted); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range persisted.Documents {
+		if movie, exists := persisted.Media[doc.Key]; exists {
+			s.index.Add(doc)
+			s.media[doc.Key] = movie
+			s.lastSeen[doc.Key] = persisted.LastSeen[doc.Key]
+		}
+	}
+	s.prune()
+
+	return nil
+}
+
+// Save persists the index next to the cache if it changed since the last save
+func (s *SearchIndexService) Save(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	persisted := persistedSearchIndex{
+		Documents: s.index.Documents(),
+		Media:     make(map[string]models.Media, len(s.media)),
+		LastSeen:  make(map[string]time.Time, len(s.lastSeen)),
+	}
+	for key, movie := range s.media {
+		persisted.Media[key] = movie
+	}
+	for key, seen := range s.lastSeen {
+		persisted.LastSeen[key] = seen
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err := utils.SaveJSONFile(s.path, persisted); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Search ranks locally indexed titles against a query. Quoted phrases must
+// match verbatim. It returns one page of hits and the total number of hits.
+func (s *SearchIndexService) Search(query string, mediaType string, page int, perPage int) ([]models.SearchHit, int) {
+	textHits := s.index.Search(query)
+
+	s.mu.RLock()
+	hits := make([]models.SearchHit, 0, len(textHits))
+	for _, textHit := range textHits {
+		movie, exists := s.media[textHit.Key]
+		if !exists {
+			continue
+		}
+		if mediaType != "" && mediaType != "all" && movie.MediaType != mediaType {
+			continue
+		}
+		hits = append(hits, models.SearchHit{
+			Media:      movie,
+			Score:      textHit.Score,
+			Highlights: textHit.Highlights,
+		})
+	}
+	s.mu.RUnlock()
+
+	// Manual pagination, clamped to [0, total] so that huge page values
+	// can't overflow
+	total := len(hits)
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 1
+	}
+	start := total
+	if page-1 <= total/perPage {
+		start = min((page-1)*perPage, total)
+	}
+	end := total
+	if perPage < total-start {
+		end = start + perPage
+	}
+
+	return hits[start:end], total
+}
+
+// ObserveMedia indexes movies and TV shows fetched from TMDB
+func (s *SearchIndexService) ObserveMedia(media []models.Media) {
+	for _, item := range media {
+		s.indexMedia(item)
+	}
+}
+
+// indexMedia builds a text document for a title and adds it to the index
+func (s *SearchIndexService) indexMedia(movie models.Media) {
+	title, originalTitle, tagline := movie.Title, movie.OriginalTitle, movie.Tagline
+	if movie.ID == 0 || title == "" || title == "Unknown Title" {
+		return
+	}
+
+	mediaType := movie.MediaType
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	key := mediaType + ":" + strconv.Itoa(movie.ID)
+
+	fields := map[string]string{
+		"title": title,
+	}
+	if originalTitle != "" && originalTitle != title {
+		fields["original_title"] = originalTitle
+	}
+	if movie.Overview != "" && movie.Overview != "No overview available" {
+		fields["overview"] = movie.Overview
+	}
+	if tagline != "" && tagline != "No tagline available" {
+		fields["tagline"] = tagline
+	}
+
+	var castNames, crewNames, keywordNames []string
+	for _, cast := range movie.Credits.Cast {
+		castNames = append(castNames, cast.Name)
+	}
+	seenCrew := make(map[int]bool)
+	for _, crew := range movie.Credits.Crew {
+		if !seenCrew[crew.ID] {
+			seenCrew[crew.ID] = true
+			crewNames = append(crewNames, crew.Name)
+		}
+	}
+	for _, keyword := range movie.Keywords {
+		keywordNames = append(keywordNames, keyword.Name)
+	}
+	if len(castNames) > 0 {
+		fields["cast"] = strings.Join(castNames, ", ")
+	}
+	if len(crewNames) > 0 {
+		fields["crew"] = strings.Join(crewNames, ", ")
+	}
+	if len(keywordNames) > 0 {
+		fields["keywords"] = strings.Join(keywordNames, ", ")
+	}
+
+	// Don't let a list result overwrite a richer details document
+	if existing, exists := s.index.Get(key); exists {
+		_, hadCredits := existing.Fields["cast"]
+		_, hadKeywords := existing.Fields["keywords"]
+		if (hadCredits || hadKeywords) && len(castNames) == 0 && len(keywordNames) == 0 {
+			s.mu.Lock()
+			s.lastSeen[key] = time.Now()
+			s.mu.Unlock()
+			return
+		}
+	}
+
+	// Keep a slim copy for rendering results
+	movie.MediaType = mediaType
+	movie.Credits = models.Credits{}
+	movie.Keywords = nil
+	movie.TVDetails = nil
+
+	s.mu.Lock()
+	s.index.Add(utils.TextDocument{Key: key, Fields: fields})
+	s.media[key] = movie
+	s.lastSeen[key] = time.Now()
+	s.dirty = true
+	s.prune()
+	s.mu.Unlock()
+}
+
+// prune drops the least recently seen titles once the index is over its
+// limit. It trims a tenth below the limit so it doesn't sort on every add.
+// Callers must hold the write lock.
+func (s *SearchIndexService) prune() {
+	if s.maxDocs <= 0 || len(s.media) <= s.maxDocs {
+		return
+	}
+
+	keys := make([]string, 0, len(s.media))
+	for key := range s.media {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.lastSeen[keys[i]].Before(s.lastSeen[keys[j]])
+	})
+
+	for _, key := range keys[:len(keys)-s.maxDocs*9/10] {
+		s.index.Remove(key)
+		delete(s.media, key)
+		delete(s.lastSeen, key)
+	}
+	s.dirty = true
+}
+
+// Close persists the index
+func (s *SearchIndexService) Close() error {
+	return s.Save(context.Background())
+}
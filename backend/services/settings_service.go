@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// SettingsService stores per-user preferences such as region and streaming
+// subscriptions
+type SettingsService struct {
+	// In a real application, you would have a database here
+	mu       sync.RWMutex
+	settings map[string]*models.UserSettings // userID -> settings
+}
+
+// NewSettingsService creates a new settings service instance
+func NewSettingsService() *SettingsService {
+	return &SettingsService{
+		settings: make(map[string]*models.UserSettings),
+	}
+}
+
+// GetSettings returns a user's settings, falling back to the default region
+// and no subscriptions for users who haven't saved any
+func (s *SettingsService) GetSettings(ctx context.Context, userID string) models.UserSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.getSettings(userID)
+}
+
+// UpdateSettings updates a user's settings
+func (s *SettingsService) UpdateSettings(ctx context.Context, userID string, request models.UserSettingsUpdateRequest) models.UserSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings := s.getSettings(userID)
+	if request.Region != nil {
+		settings.Region = strings.ToUpper(*request.Region)
+	}
+	if request.ProviderIDs != nil {
+		// Drop duplicates while keeping the user's order
+		seen := make(map[int]bool)
+		settings.ProviderIDs = []int{}
+		for _, id := range request.ProviderIDs {
+			if !seen[id] {
+				seen[id] = true
+				settings.ProviderIDs = append(settings.ProviderIDs, id)
+			}
+		}
+	}
+	settings.UpdatedAt = time.Now()
+
+	stored := settings
+	s.settings[userID] = &stored
+
+	return settings
+}
+
+// FollowPerson adds a person to the people a user follows
+func (s *SettingsService) FollowPerson(ctx context.Context, userID string, personID int) models.UserSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings := s.getSettings(userID)
+	for _, id := range settings.FollowedPeople {
+		if id == personID {
+			return settings
+		}
+	}
+	settings.FollowedPeople = append(settings.FollowedPeople, personID)
+	settings.UpdatedAt = time.Now()
+
+	stored := settings
+	s.settings[userID] = &stored
+
+	return settings
+}
+
+// UnfollowPerson removes a person from the people a user follows
+func (s *SettingsService) UnfollowPerson(ctx context.Context, userID string, personID int) models.UserSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings := s.getSettings(userID)
+	followed := []int{}
+	for _, id := range settings.FollowedPeople {
+		if id != personID {
+			followed = append(followed, id)
+		}
+	}
+	settings.FollowedPeople = followed
+	settings.UpdatedAt = time.Now()
+
+	stored := settings
+	s.settings[userID] = &stored
+
+	return settings
+}
+
+// GetFollowers returns the users following each followed person
+func (s *SettingsService) GetFollowers() map[int][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	followers := make(map[int][]string)
+	for userID, settings := range s.settings {
+		for _, personID := range settings.FollowedPeople {
+			followers[personID] = append(followers[personID], userID)
+		}
+	}
+
+	return followers
+}
+
+// GetFollowedPeople returns the people each user follows
+func (s *SettingsService) GetFollowedPeople() map[string][]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	follows := make(map[string][]int)
+	for userID, settings := range s.settings {
+		if len(settings.FollowedPeople) > 0 {
+			follows[userID] = append([]int{}, settings.FollowedPeople...)
+		}
+	}
+
+	return follows
+}
+
+// RestoreFollowedPeople sets the people each user follows, as persisted by a
+// previous run
+func (s *SettingsService) RestoreFollowedPeople(follows map[string][]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, personIDs := range follows {
+		settings := s.getSettings(userID)
+		settings.FollowedPeople = append([]int{}, personIDs...)
+		stored := settings
+		s.settings[userID] = &stored
+	}
+}
+
+// getSettings returns a copy of a user's settings; callers must hold the lock
+func (s *SettingsService) getSettings(userID string) models.UserSettings {
+	if settings, exists := s.settings[userID]; exists {
+		result := *settings
+		result.ProviderIDs = append([]int{}, settings.ProviderIDs...)
+		result.FollowedPeople = append([]int{}, settings.FollowedPeople...)
+		return result
+	}
+
+	return models.UserSettings{
+		UserID:         userID,
+		Region:         config.AppConfig.TMDB.Region,
+		ProviderIDs:    []int{},
+		FollowedPeople: []int{},
+	}
+}
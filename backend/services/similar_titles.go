@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// Similar title tuning
+const (
+	similarTitlesTTL    = 30 * time.Minute
+	similarCastSize     = 10 // top-billed cast members compared
+	similarPeopleTarget = 3  // shared people for a full people score
+	similarOverviewTerm = 3  // shortest overview word that counts
+	maxOverviewTerms    = 5  // shared overview terms reported
+)
+
+// How much each signal counts toward a similar title's score
+const (
+	similarGenreWeight      = 0.2
+	similarKeywordWeight    = 0.25
+	similarPeopleWeight     = 0.2
+	similarCollectionWeight = 0.1
+	similarOverviewWeight   = 0.15
+	similarTMDBWeight       = 0.1
+)
+
+// similarCrewJobs are the crew jobs that make two titles feel alike
+var similarCrewJobs = map[string]bool{
+	"Director":                true,
+	"Screenplay":              true,
+	"Writer":                  true,
+	"Novel":                   true,
+	"Original Music Composer": true,
+	"Director of Photography": true,
+}
+
+// overviewStopWords are common words that say nothing about a story
+var overviewStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "his": true, "her": true,
+	"their": true, "they": true, "from": true, "into": true, "that": true, "this": true,
+	"who": true, "when": true, "what": true, "but": true, "are": true, "was": true,
+	"has": true, "have": true, "its": true, "after": true, "while": true, "out": true,
+	"one": true, "two": true, "must": true, "only": true, "him": true, "she": true,
+	"them": true, "about": true, "which": true, "where": true, "will": true, "all": true,
+	"own": true, "can": true, "more": true, "than": true, "not": true, "new": true,
+	"becomes": true, "finds": true, "find": true, "take": true, "takes": true, "life": true,
+}
+
+// similarCandidate is a title in the similar title pool
+type similarCandidate struct {
+	media   models.Media
+	sources []string
+}
+
+// GetSimilarTitles finds movies or TV shows similar to a title. Candidates
+// come from TMDB's recommendations and similar lists and are ranked locally
+// by shared genres, keywords, cast and crew, collection and the TF-IDF
+// similarity of their overviews.
+func (s *WatchlistService) GetSimilarTitles(ctx context.Context, mediaType string, id int, limit int) ([]models.SimilarTitle, error) {
+	if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		return nil, fmt.Errorf("invalid media type: %s", mediaType)
+	}
+
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("similar_titles", mediaType, id, limit)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if titles, ok := cached.([]models.SimilarTitle); ok {
+			return titles, nil
+		}
+	}
+
+	// Get the source title
+	source, err := s.getMediaDetails(ctx, mediaType, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source title: %w", err)
+	}
+
+	// Gather candidates from both TMDB lists
+	kinds := []string{RelatedRecommendations, RelatedSimilar}
+	lists := make([][]models.Media, len(kinds))
+	errs := make([]error, len(kinds))
+	runPool(ctx, len(kinds), func(i int) {
+		lists[i], errs[i] = s.tmdbService.GetRelatedMedia(ctx, mediaType, id, kinds[i], 1)
+	})
+	if errs[0] != nil && errs[1] != nil {
+		return nil, fmt.Errorf("failed to get similar titles: %w", errs[0])
+	}
+
+	byID := make(map[int]*similarCandidate)
+	var pool []*similarCandidate
+	for i, list := range lists {
+		for _, media := range list {
+			if media.ID == id || media.Adult {
+				continue
+			}
+			candidate, exists := byID[media.ID]
+			if !exists {
+				candidate = &similarCandidate{media: media}
+				byID[media.ID] = candidate
+				pool = append(pool, candidate)
+			}
+			candidate.sources = append(candidate.sources, kinds[i])
+		}
+	}
+
+	// Fetch candidates in full for keywords, credits and collections
+	runPool(ctx, len(pool), func(i int) {
+		if details, err := s.getMediaDetails(ctx, mediaType, pool[i].media.ID); err == nil {
+			pool[i].media = *details
+		}
+	})
+
+	// Score against the source
+	overviews := newOverviewIndex(source.Overview, pool)
+	titles := make([]models.SimilarTitle, len(pool))
+	for i, candidate := range pool {
+		similarity, terms := overviews.similarity(i)
+		titles[i] = scoreSimilarTitle(*source, candidate, similarity, terms)
+	}
+
+	// Sort by score (highest first)
+	sort.SliceStable(titles, func(i, j int) bool {
+		return titles[i].Score > titles[j].Score
+	})
+
+	// Limit results
+	if len(titles) > limit {
+		titles = titles[:limit]
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, titles, similarTitlesTTL)
+
+	return titles, nil
+}
+
+// scoreSimilarTitle compares a candidate with the source title
+func scoreSimilarTitle(source models.Media, candidate *similarCandidate, overviewSimilarity float64, overviewTerms []string) models.SimilarTitle {
+	media := candidate.media
+	shared := models.SharedFeatures{
+		Genres:        []models.Genre{},
+		Keywords:      []models.Keyword{},
+		People:        []models.SharedPerson{},
+		OverviewTerms: overviewTerms,
+		Sources:       candidate.sources,
+	}
+	var signals models.SimilaritySignals
+
+	// Genres, named from the source which is always fetched in full
+	genreNames := make(map[int]string, len(source.Genres))
+	for _, genre := range source.Genres {
+		genreNames[genre.ID] = genre.Name
+	}
+	sharedGenres, genreUnion := overlap(mediaGenreIDs(source), mediaGenreIDs(media))
+	for _, genreID := range sharedGenres {
+		shared.Genres = append(shared.Genres, models.Genre{ID: genreID, Name: genreNames[genreID]})
+	}
+	if genreUnion > 0 {
+		signals.Genres = float64(len(sharedGenres)) / float64(genreUnion)
+	}
+
+	// Keywords
+	keywordNames := make(map[int]string, len(source.Keywords))
+	sourceKeywords := make([]int, len(source.Keywords))
+	for i, keyword := range source.Keywords {
+		keywordNames[keyword.ID] = keyword.Name
+		sourceKeywords[i] = keyword.ID
+	}
+	candidateKeywords := make([]int, len(media.Keywords))
+	for i, keyword := range media.Keywords {
+		candidateKeywords[i] = keyword.ID
+	}
+	sharedKeywords, keywordUnion := overlap(sourceKeywords, candidateKeywords)
+	for _, keywordID := range sharedKeywords {
+		shared.Keywords = append(shared.Keywords, models.Keyword{ID: keywordID, Name: keywordNames[keywordID]})
+	}
+	if keywordUnion > 0 {
+		signals.Keywords = float64(len(sharedKeywords)) / float64(keywordUnion)
+	}
+
+	// Cast and key crew
+	candidatePeople := make(map[int]bool)
+	for _, person := range keyPeople(media) {
+		candidatePeople[person.ID] = true
+	}
+	seen := make(map[int]bool)
+	for _, person := range keyPeople(source) {
+		if candidatePeople[person.ID] && !seen[person.ID] {
+			seen[person.ID] = true
+			shared.People = append(shared.People, person)
+		}
+	}
+	signals.People = math.Min(float64(len(shared.People))/similarPeopleTarget, 1)
+
+	// Collection
+	if collection := source.Collection(); collection != nil {
+		if other := media.Collection(); other != nil && other.ID == collection.ID {
+			shared.Collection = collection
+			signals.Collection = 1
+		}
+	}
+
+	signals.Overview = overviewSimilarity
+	signals.TMDB = float64(len(candidate.sources)) / 2
+
+	score := signals.Genres*similarGenreWeight +
+		signals.Keywords*similarKeywordWeight +
+		signals.People*similarPeopleWeight +
+		signals.Collection*similarCollectionWeight +
+		signals.Overview*similarOverviewWeight +
+		signals.TMDB*similarTMDBWeight
+
+	return models.SimilarTitle{
+		Media:   media,
+		Score:   score,
+		Signals: signals,
+		Shared:  shared,
+	}
+}
+
+// keyPeople returns the top-billed cast, the crew in jobs that shape a title
+// and a show's creators
+func keyPeople(media models.Media) []models.SharedPerson {
+	var people []models.SharedPerson
+	for i, cast := range media.Credits.Cast {
+		if i == similarCastSize {
+			break
+		}
+		people = append(people, models.SharedPerson{ID: cast.ID, Name: cast.Name, Role: "cast"})
+	}
+	for _, crew := range media.Credits.Crew {
+		if similarCrewJobs[crew.Job] {
+			people = append(people, models.SharedPerson{ID: crew.ID, Name: crew.Name, Role: crew.Job})
+		}
+	}
+	if media.TVDetails != nil {
+		for _, creator := range media.CreatedBy {
+			people = append(people, models.SharedPerson{ID: creator.ID, Name: creator.Name, Role: "Creator"})
+		}
+	}
+	return people
+}
+
+// overlap returns the IDs two lists share, in the order of the first, and
+// the size of their union
+func overlap(a, b []int) ([]int, int) {
+	inB := make(map[int]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+
+	shared := []int{}
+	inA := make(map[int]bool, len(a))
+	for _, id := range a {
+		if inA[id] {
+			continue
+		}
+		inA[id] = true
+		if inB[id] {
+			shared = append(shared, id)
+		}
+	}
+
+	return shared, len(inA) + len(inB) - len(shared)
+}
+
+// overviewIndex holds TF-IDF vectors of the source overview and every
+// candidate's, with document frequencies taken across all of them
+type overviewIndex struct {
+	source     map[string]float64
+	candidates []map[string]float64
+}
+
+// newOverviewIndex builds TF-IDF vectors for the source and candidate
+// overviews
+func newOverviewIndex(sourceOverview string, pool []*similarCandidate) *overviewIndex {
+	documents := make([]map[string]int, len(pool)+1)
+	documents[0] = overviewTerms(sourceOverview)
+	for i, candidate := range pool {
+		documents[i+1] = overviewTerms(candidate.media.Overview)
+	}
+
+	frequencies := make(map[string]int)
+	for _, terms := range documents {
+		for term := range terms {
+			frequencies[term]++
+		}
+	}
+
+	vectors := make([]map[string]float64, len(documents))
+	for i, terms := range documents {
+		total := 0
+		for _, count := range terms {
+			total += count
+		}
+		vector := make(map[string]float64, len(terms))
+		for term, count := range terms {
+			idf := math.Log(float64(len(documents)+1)/float64(frequencies[term]+1)) + 1
+			vector[term] = float64(count) / float64(total) * idf
+		}
+		vectors[i] = vector
+	}
+
+	return &overviewIndex{source: vectors[0], candidates: vectors[1:]}
+}
+
+// similarity returns the cosine similarity of a candidate's overview to the
+// source's, along with the shared terms that weigh most
+func (index *overviewIndex) similarity(i int) (float64, []string) {
+	candidate := index.candidates[i]
+
+	type sharedTerm struct {
+		term   string
+		weight float64
+	}
+	var terms []sharedTerm
+	dot, sourceNorm, candidateNorm := 0.0, 0.0, 0.0
+	for term, weight := range index.source {
+		sourceNorm += weight * weight
+		if other, exists := candidate[term]; exists {
+			dot += weight * other
+			terms = append(terms, sharedTerm{term: term, weight: weight * other})
+		}
+	}
+	for _, weight := range candidate {
+		candidateNorm += weight * weight
+	}
+	if dot == 0 {
+		return 0, []string{}
+	}
+
+	sort.Slice(terms, func(a, b int) bool {
+		if terms[a].weight != terms[b].weight {
+			return terms[a].weight > terms[b].weight
+		}
+		return terms[a].term < terms[b].term
+	})
+	shared := []string{}
+	for j := 0; j < len(terms) && j < maxOverviewTerms; j++ {
+		shared = append(shared, terms[j].term)
+	}
+
+	return dot / (math.Sqrt(sourceNorm) * math.Sqrt(candidateNorm)), shared
+}
+
+// overviewTerms counts the words of an overview that can tell stories apart
+func overviewTerms(overview string) map[string]int {
+	terms := make(map[string]int)
+	for _, token := range utils.Tokenize(overview) {
+		if len(token) >= similarOverviewTerm && !overviewStopWords[token] {
+			terms[token]++
+		}
+	}
+	return terms
+}
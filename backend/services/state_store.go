@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// StateStore holds JSON state that every replica must see, such as the
+// trending history, watch parties and calendar feed tokens
+type StateStore interface {
+	// Load reads the named state into v. Missing state is not an error and
+	// leaves v untouched.
+	Load(ctx context.Context, name string, v interface{}) error
+	// Save replaces the named state with v
+	Save(ctx context.Context, name string, v interface{}) error
+}
+
+// FileStateStore implements StateStore with JSON files in a local directory.
+// Replicas don't share it, so it only suits a single instance.
+type FileStateStore struct {
+	dir string
+}
+
+// NewFileStateStore creates a store that keeps its files in dir
+func NewFileStateStore(dir string) *FileStateStore {
+	return &FileStateStore{dir: dir}
+}
+
+// Load reads the named state from its file
+func (s *FileStateStore) Load(ctx context.Context, name string, v interface{}) error {
+	return utils.LoadJSONFile(filepath.Join(s.dir, name+".json"), v)
+}
+
+// Save writes the named state to its file
+func (s *FileStateStore) Save(ctx context.Context, name string, v interface{}) error {
+	return utils.SaveJSONFile(filepath.Join(s.dir, name+".json"), v)
+}
+
+// RedisStateStore implements StateStore with JSON values in Redis
+type RedisStateStore struct {
+	client *utils.RedisClient
+	prefix string
+}
+
+// NewRedisStateStore creates a store that keeps its values under prefix
+func NewRedisStateStore(client *utils.RedisClient, prefix string) *RedisStateStore {
+	return &RedisStateStore{client: client, prefix: prefix}
+}
+
+// Load reads the named state from Redis
+func (s *RedisStateStore) Load(ctx context.Context, name string, v interface{}) error {
+	reply, err := s.client.Do(ctx, "GET", s.prefix+name)
+	if err != nil {
+		if errors.Is(err, utils.ErrRedisNil) {
+			return nil
+		}
+		return fmt.Errorf("failed to load %s: %w", name, err)
+	}
+
+	data, _ := reply.(string)
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Save writes the named state to Redis
+func (s *RedisStateStore) Save(ctx context.Context, name string, v interface{}) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	if _, err := s.client.Do(ctx, "SET", s.prefix+name, string(encoded)); err != nil {
+		return fmt.Errorf("failed to save %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// SuggestService serves typeahead suggestions from a local prefix index so
+// that the search box never has to wait on TMDB
+type SuggestService struct {
+	tmdbService      *TMDBService
+	watchlistService *WatchlistService
+
+	mu      sync.RWMutex
+	entries map[string]*suggestEntry
+	index   *utils.PrefixIndex
+}
+
+// suggestEntry represents an indexed suggestion
+type suggestEntry struct {
+	suggestion models.Suggestion
+	normalized string
+	popularity float64
+}
+
+// typeWeights nudges suggestion types relative to each other
+var typeWeights = map[string]float64{
+	models.SuggestionMovie:      0.2,
+	models.SuggestionTV:         0.2,
+	models.SuggestionPerson:     0.1,
+	models.SuggestionGenre:      0.3,
+	models.SuggestionCollection: 0.1,
+}
+
+// NewSuggestService creates a new suggest service instance and subscribes it
+// to titles fetched by the TMDB service
+func NewSuggestService(tmdbService *TMDBService, watchlistService *WatchlistService) *SuggestService {
+	s := &SuggestService{
+		tmdbService:      tmdbService,
+		watchlistService: watchlistService,
+		entries:          make(map[string]*suggestEntry),
+		index:            utils.NewPrefixIndex(),
+	}
+	tmdbService.AddObserver(s)
+	return s
+}
+
+// Suggest returns the best matching suggestions for a partial query
+func (s *SuggestService) Suggest(ctx context.Context, userID string, query string, limit int, types []string) []models.Suggestion {
+	tokens := utils.Tokenize(query)
+	if len(tokens) == 0 {
+		return []models.Suggestion{}
+	}
+
+	// Every query token must match some indexed term of the entry
+	var distances map[string]int
+	for _, token := range tokens {
+		matches := s.index.SearchPrefix(token, utils.MaxEditsFor(token))
+		if distances == nil {
+			distances = matches
+			continue
+		}
+		for key, dist := range distances {
+			if tokenDist, ok := matches[key]; ok {
+				distances[key] = dist + tokenDist
+			} else {
+				delete(distances, key)
+			}
+		}
+	}
+
+	allowed := make(map[string]bool)
+	for _, t := range types {
+		allowed[t] = true
+	}
+
+	watchlisted := map[string]map[int]bool{}
+	if userID != "" {
+		watchlisted = s.watchlistService.GetWatchlistMediaIDs(userID)
+	}
+
+	normalizedQuery := strings.Join(tokens, " ")
+
+	s.mu.RLock()
+	suggestions := make([]models.Suggestion, 0, len(distances))
+	for key, dist := range distances {
+		entry, exists := s.entries[key]
+		if !exists {
+			continue
+		}
+		if len(allowed) > 0 && !allowed[entry.suggestion.Type] {
+			continue
+		}
+
+		suggestion := entry.suggestion
+
+		// Text match quality
+		score := 1.0 - 0.25*float64(dist)
+		if entry.normalized == normalizedQuery {
+			score += 1.0
+		} else if strings.HasPrefix(entry.normalized, normalizedQuery) {
+			score += 0.5
+		}
+
+		// Popularity and type weighting
+		score += math.Log1p(entry.popularity) / 10.0
+		score += typeWeights[suggestion.Type]
+
+		// Boost items already in the user's watchlist
+		if watchlisted[suggestion.Type][suggestion.ID] {
+			suggestion.InWatchlist = true
+			score += 1.0
+		}
+
+		suggestion.Score = math.Round(score*1000) / 1000
+		suggestions = append(suggestions, suggestion)
+	}
+	s.mu.RUnlock()
+
+	// Sort by score (highest first), then alphabetically for stable output
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Label < suggestions[j].Label
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// Refresh rebuilds the index sources from trending, popular and genre data.
+// Previously seen titles stay in the index.
+func (s *SuggestService) Refresh(ctx context.Context) error {
+	var failures []string
+
+	// Trending titles
+	for _, timeframe := range []string{"day", "week"} {
+		for _, mediaType := range []string{"movie", "tv"} {
+			trending, err := s.tmdbService.GetTrendingMedia(ctx, timeframe, 1, mediaType)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("trending %s/%s: %v", mediaType, timeframe, err))
+				continue
+			}
+			s.ObserveMedia(trending.Results)
+		}
+	}
+
+	// Popular titles
+	for _, mediaType := range []string{"movie", "tv"} {
+		for page := 1; page <= 2; page++ {
+			popular, err := s.tmdbService.GetPopularMedia(ctx, mediaType, page)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("popular %s: %v", mediaType, err))
+				continue
+			}
+			s.ObserveMedia(popular.Results)
+		}
+	}
+
+	// Trending people
+	for _, timeframe := range []string{"day", "week"} {
+		people, err := s.tmdbService.GetTrendingPeople(ctx, timeframe)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("trending people/%s: %v", timeframe, err))
+			continue
+		}
+		for _, person := range people {
+			s.addPerson(person.ID, person.Name, person.KnownForDepartment, person.ProfilePath, person.Popularity)
+		}
+	}
+
+	// Movie and TV genres
+	genres, err := s.tmdbService.GetGenres(ctx)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("genres: %v", err))
+	}
+	tvGenres, err := s.tmdbService.GetTVGenres(ctx)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("tv genres: %v", err))
+	}
+	for _, genre := range append(genres, tvGenres...) {
+		s.add(models.Suggestion{
+			Type:     models.SuggestionGenre,
+			ID:       genre.ID,
+			Label:    genre.Name,
+			Subtitle: "Genre",
+		}, 50)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to refresh %d suggestion sources: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// ObserveMedia indexes movies and TV shows fetched from TMDB
+func (s *SuggestService) ObserveMedia(media []models.Media) {
+	for _, item := range media {
+		if item.Title == "Unknown Title" {
+			continue
+		}
+
+		suggestionType := models.SuggestionMovie
+		if item.IsTV() {
+			suggestionType = models.SuggestionTV
+		}
+
+		s.add(models.Suggestion{
+			Type:      suggestionType,
+			ID:        item.ID,
+			Label:     item.Title,
+			Subtitle:  titleSubtitle(suggestionType, item.ReleaseDate),
+			ImagePath: item.PosterPath,
+		}, item.Popularity)
+
+		if collection := item.Collection(); collection != nil {
+			s.add(models.Suggestion{
+				Type:      models.SuggestionCollection,
+				ID:        collection.ID,
+				Label:     collection.Name,
+				Subtitle:  "Collection",
+				ImagePath: collection.PosterPath,
+			}, item.Popularity)
+		}
+
+		// Top billed cast, directors and creators from details responses
+		for _, cast := range item.Credits.Cast {
+			if cast.Order < 5 {
+				s.addPerson(cast.ID, cast.Name, "Acting", cast.ProfilePath, item.Popularity/10)
+			}
+		}
+		for _, crew := range item.Credits.Crew {
+			if crew.Job == "Director" {
+				s.addPerson(crew.ID, crew.Name, crew.Department, crew.ProfilePath, item.Popularity/10)
+			}
+		}
+		if item.TVDetails != nil {
+			for _, creator := range item.CreatedBy {
+				s.addPerson(creator.ID, creator.Name, "Creator", creator.ProfilePath, item.Popularity/10)
+			}
+		}
+	}
+}
+
+// addPerson indexes a person suggestion
+func (s *SuggestService) addPerson(id int, name string, department string, profilePath string, popularity float64) {
+	s.add(models.Suggestion{
+		Type:      models.SuggestionPerson,
+		ID:        id,
+		Label:     name,
+		Subtitle:  department,
+		ImagePath: profilePath,
+	}, popularity)
+}
+
+// add inserts or updates an entry and indexes its label terms
+func (s *SuggestService) add(suggestion models.Suggestion, popularity float64) {
+	normalized := utils.NormalizeText(suggestion.Label)
+	if normalized == "" || suggestion.ID == 0 {
+		return
+	}
+
+	key := suggestion.Type + ":" + strconv.Itoa(suggestion.ID)
+
+	s.mu.Lock()
+	existing, exists := s.entries[key]
+	if exists {
+		// Keep the highest popularity seen and any richer metadata
+		if popularity < existing.popularity {
+			popularity = existing.popularity
+		}
+		if suggestion.ImagePath == "" {
+			suggestion.ImagePath = existing.suggestion.ImagePath
+		}
+	}
+	s.entries[key] = &suggestEntry{
+		suggestion: suggestion,
+		normalized: normalized,
+		popularity: popularity,
+	}
+
+	if exists && existing.normalized == normalized {
+		s.mu.Unlock()
+		return
+	}
+
+	// Drop the terms of a renamed entry's old label so it stops matching them
+	if exists {
+		for _, term := range labelTerms(existing.normalized) {
+			s.index.Remove(term, key)
+		}
+	}
+	for _, term := range labelTerms(normalized) {
+		s.index.Insert(term, key)
+	}
+	s.mu.Unlock()
+}
+
+// labelTerms returns the indexed terms of a normalized label: each word and
+// the whole label without spaces ("spiderman")
+func labelTerms(normalized string) []string {
+	return append(strings.Fields(normalized), strings.ReplaceAll(normalized, " ", ""))
+}
+
+// titleSubtitle builds a "2010 · Movie" style subtitle
+func titleSubtitle(suggestionType string, date string) string {
+	label := "Movie"
+	if suggestionType == models.SuggestionTV {
+		label = "TV Show"
+	}
+	if year := utils.ParseYear(date); year > 0 {
+		return fmt.Sprintf("%d · %s", year, label)
+	}
+	return label
+}
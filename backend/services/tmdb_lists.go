@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// Curated TMDB lists
+const (
+	ListPopular     = "popular"
+	ListTopRated    = "top_rated"
+	ListUpcoming    = "upcoming"
+	ListNowPlaying  = "now_playing"
+	ListAiringToday = "airing_today"
+	ListOnTheAir    = "on_the_air"
+)
+
+// mediaLists holds the lists TMDB offers for each media type
+var mediaLists = map[string]map[string]bool{
+	models.MediaTypeMovie: {ListPopular: true, ListTopRated: true, ListUpcoming: true, ListNowPlaying: true},
+	models.MediaTypeTV:    {ListPopular: true, ListTopRated: true, ListAiringToday: true, ListOnTheAir: true},
+}
+
+// IsMediaList reports whether TMDB offers a list for a media type
+func IsMediaList(mediaType string, list string) bool {
+	return mediaLists[mediaType][list]
+}
+
+// IsRegionalList reports whether a list's contents depend on the region.
+// Movie lists follow regional release dates; TV lists are global.
+func IsRegionalList(mediaType string, list string) bool {
+	return mediaType == models.MediaTypeMovie && IsMediaList(mediaType, list)
+}
+
+// listTTL returns how long a list stays cached; lists that change with the
+// calendar expire sooner than all-time rankings
+func listTTL(list string) time.Duration {
+	switch list {
+	case ListTopRated:
+		return config.AppConfig.Cache.TopRatedTTL
+	case ListUpcoming:
+		return config.AppConfig.Cache.UpcomingTTL
+	case ListNowPlaying:
+		return config.AppConfig.Cache.NowPlayingTTL
+	case ListAiringToday, ListOnTheAir:
+		return config.AppConfig.Cache.AiringTTL
+	default:
+		return config.AppConfig.Cache.PopularTTL
+	}
+}
+
+// GetMediaList retrieves a page of a curated movie or TV list. Movie lists
+// are narrowed to releases in region when one is given.
+func (s *TMDBService) GetMediaList(ctx context.Context, mediaType string, list string, region string, page int) (*models.MovieSearchResult, error) {
+	if !IsMediaList(mediaType, list) {
+		return nil, fmt.Errorf("unknown %s list %q", mediaType, list)
+	}
+	if !IsRegionalList(mediaType, list) {
+		region = ""
+	}
+
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_list", mediaType, list, region, page)
+
+	// Check cache first
+	if cached, exists := s.cacheGet(ctx, cacheKey); exists {
+		if result, ok := cached.(*models.MovieSearchResult); ok {
+			return result, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/%s/%s", s.config.BaseURL, mediaType, list)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("language", "en-US")
+	if region != "" {
+		params.Set("region", region)
+	}
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", list, mediaType, err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	result := &models.MovieSearchResult{}
+	if mediaType == models.MediaTypeTV {
+		var tvResp TMDBTVListResponse
+		if err := json.Unmarshal(body, &tvResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		result.Page, result.TotalPages, result.TotalResults = tvResp.Page, tvResp.TotalPages, tvResp.TotalResults
+		result.Results = make([]models.Media, len(tvResp.Results))
+		for i, tv := range tvResp.Results {
+			result.Results[i] = s.convertTMDBTVResult(tv)
+		}
+	} else {
+		var tmdbResp struct {
+			TMDBSearchResponse
+			Dates *models.DateRange `json:"dates"`
+		}
+		if err := json.Unmarshal(body, &tmdbResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		result.Page, result.TotalPages, result.TotalResults = tmdbResp.Page, tmdbResp.TotalPages, tmdbResp.TotalResults
+		result.Dates = tmdbResp.Dates
+		result.Results = make([]models.Media, len(tmdbResp.Results))
+		for i, tmdbMovie := range tmdbResp.Results {
+			result.Results[i] = *s.convertTMDBMovie(tmdbMovie)
+		}
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, result, listTTL(list))
+
+	return result, nil
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// TMDBPersonResponse represents TMDB person response
+type TMDBPersonResponse struct {
+	ID                 int                `json:"id"`
+	Name               string             `json:"name"`
+	ProfilePath        string             `json:"profile_path"`
+	KnownForDepartment string             `json:"known_for_department"`
+	Popularity         float64            `json:"popularity"`
+	Gender             int                `json:"gender"`
+	Biography          string             `json:"biography"`
+	Birthday           string             `json:"birthday"`
+	Deathday           string             `json:"deathday"`
+	PlaceOfBirth       string             `json:"place_of_birth"`
+	AlsoKnownAs        []string           `json:"also_known_as"`
+	Homepage           string             `json:"homepage"`
+	IMDBID             string             `json:"imdb_id"`
+	KnownFor           []TMDBCreditResult `json:"known_for"`
+	Images             struct {
+		Profiles []TMDBImage `json:"profiles"`
+	} `json:"images"`
+}
+
+// TMDBImage represents an image entry in TMDB responses
+type TMDBImage struct {
+	FilePath    string  `json:"file_path"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	AspectRatio float64 `json:"aspect_ratio"`
+	VoteAverage float64 `json:"vote_average"`
+}
+
+// TMDBCreditResult represents a movie or TV credit in TMDB person responses
+type TMDBCreditResult struct {
+	ID           int     `json:"id"`
+	MediaType    string  `json:"media_type"`
+	Title        string  `json:"title"`
+	Name         string  `json:"name"`
+	ReleaseDate  string  `json:"release_date"`
+	FirstAirDate string  `json:"first_air_date"`
+	PosterPath   string  `json:"poster_path"`
+	Character    string  `json:"character"`
+	Job          string  `json:"job"`
+	Department   string  `json:"department"`
+	EpisodeCount int     `json:"episode_count"`
+	GenreIDs     []int   `json:"genre_ids"`
+	VoteAverage  float64 `json:"vote_average"`
+	VoteCount    int     `json:"vote_count"`
+	Popularity   float64 `json:"popularity"`
+}
+
+// TMDBPersonListResponse represents a page of people from TMDB
+type TMDBPersonListResponse struct {
+	Page         int                  `json:"page"`
+	Results      []TMDBPersonResponse `json:"results"`
+	TotalPages   int                  `json:"total_pages"`
+	TotalResults int                  `json:"total_results"`
+}
+
+// TMDBCombinedCreditsResponse represents TMDB combined credits response
+type TMDBCombinedCreditsResponse struct {
+	ID   int                `json:"id"`
+	Cast []TMDBCreditResult `json:"cast"`
+	Crew []TMDBCreditResult `json:"crew"`
+}
+
+// SearchPeople searches for people by name
+func (s *TMDBService) SearchPeople(ctx context.Context, query string, page int) (*models.PersonSearchResult, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_person_search", query, page)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if result, ok := cached.(*models.PersonSearchResult); ok {
+			return result, nil
+		}
+	}
+
+	// Build URL
+	baseURL := s.config.BaseURL + "/search/person"
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("query", query)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("include_adult", "false")
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search people: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbResp TMDBPersonListResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	people := make([]models.Person, len(tmdbResp.Results))
+	for i, person := range tmdbResp.Results {
+		people[i] = s.convertTMDBPerson(person)
+	}
+
+	result := &models.PersonSearchResult{
+		Page:         tmdbResp.Page,
+		Results:      people,
+		TotalPages:   tmdbResp.TotalPages,
+		TotalResults: tmdbResp.TotalResults,
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, result, config.AppConfig.Cache.SearchTTL)
+
+	return result, nil
+}
+
+// GetPersonDetails retrieves a person's profile, biography and images
+func (s *TMDBService) GetPersonDetails(ctx context.Context, personID int) (*models.Person, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_person", personID)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if person, ok := cached.(*models.Person); ok {
+			return person, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/person/%d", s.config.BaseURL, personID)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("append_to_response", "images")
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person details: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbPerson TMDBPersonResponse
+	if err := json.Unmarshal(body, &tmdbPerson); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if tmdbPerson.ID == 0 {
+		return nil, fmt.Errorf("person %d not found", personID)
+	}
+
+	// Convert to our model
+	person := s.convertTMDBPerson(tmdbPerson)
+
+	// Cache the result
+	s.cache.Set(cacheKey, &person, config.AppConfig.Cache.TTL)
+
+	return &person, nil
+}
+
+// GetPersonCredits retrieves a person's combined movie and TV filmography.
+// sortBy is "date" (newest first) or "popularity" (most popular first).
+func (s *TMDBService) GetPersonCredits(ctx context.Context, personID int, sortBy string) (*models.PersonCredits, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_person_credits", personID)
+
+	// Check cache first
+	var credits *models.PersonCredits
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if c, ok := cached.(*models.PersonCredits); ok {
+			credits = c
+		}
+	}
+
+	if credits == nil {
+		// Build URL
+		baseURL := fmt.Sprintf("%s/person/%d/combined_credits", s.config.BaseURL, personID)
+		params := url.Values{}
+		params.Set("api_key", s.config.APIKey)
+		params.Set("language", "en-US")
+
+		// Make request
+		resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get person credits: %w", err)
+		}
+		defer resp.Body.Close()
+
+		// Read response
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		// Parse response
+		var tmdbCredits TMDBCombinedCreditsResponse
+		if err := json.Unmarshal(body, &tmdbCredits); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		// Convert to our model
+		credits = &models.PersonCredits{
+			PersonID: personID,
+			Cast:     make([]models.PersonCredit, len(tmdbCredits.Cast)),
+			Crew:     make([]models.PersonCredit, len(tmdbCredits.Crew)),
+		}
+		for i, credit := range tmdbCredits.Cast {
+			credits.Cast[i] = convertTMDBCredit(credit)
+		}
+		for i, credit := range tmdbCredits.Crew {
+			credits.Crew[i] = convertTMDBCredit(credit)
+		}
+
+		// Cache the result
+		s.cache.Set(cacheKey, credits, config.AppConfig.Cache.TTL)
+	}
+
+	// Sort a copy so the cached filmography is never reordered concurrently
+	sorted := &models.PersonCredits{
+		PersonID: credits.PersonID,
+		Cast:     append([]models.PersonCredit(nil), credits.Cast...),
+		Crew:     append([]models.PersonCredit(nil), credits.Crew...),
+	}
+	sortPersonCredits(sorted.Cast, sortBy)
+	sortPersonCredits(sorted.Crew, sortBy)
+
+	return sorted, nil
+}
+
+// sortPersonCredits orders credits by release date or popularity, newest or
+// most popular first. Credits without a date sort last.
+func sortPersonCredits(credits []models.PersonCredit, sortBy string) {
+	sort.SliceStable(credits, func(i, j int) bool {
+		if sortBy == "popularity" {
+			return credits[i].Popularity > credits[j].Popularity
+		}
+		if credits[i].ReleaseDate == "" || credits[j].ReleaseDate == "" {
+			return credits[j].ReleaseDate == "" && credits[i].ReleaseDate != ""
+		}
+		return credits[i].ReleaseDate > credits[j].ReleaseDate
+	})
+}
+
+// convertTMDBPerson converts TMDB person response to our model
+func (s *TMDBService) convertTMDBPerson(tmdbPerson TMDBPersonResponse) models.Person {
+	person := models.Person{
+		ID:                 tmdbPerson.ID,
+		Name:               tmdbPerson.Name,
+		ProfilePath:        tmdbPerson.ProfilePath,
+		KnownForDepartment: tmdbPerson.KnownForDepartment,
+		Popularity:         tmdbPerson.Popularity,
+		Gender:             tmdbPerson.Gender,
+		Biography:          tmdbPerson.Biography,
+		Birthday:           tmdbPerson.Birthday,
+		Deathday:           tmdbPerson.Deathday,
+		PlaceOfBirth:       tmdbPerson.PlaceOfBirth,
+		AlsoKnownAs:        tmdbPerson.AlsoKnownAs,
+		Homepage:           tmdbPerson.Homepage,
+		IMDBID:             tmdbPerson.IMDBID,
+	}
+
+	for _, image := range tmdbPerson.Images.Profiles {
+		person.Images = append(person.Images, convertTMDBImage(image))
+	}
+
+	for _, credit := range tmdbPerson.KnownFor {
+		person.KnownFor = append(person.KnownFor, convertTMDBCredit(credit))
+	}
+
+	return person
+}
+
+// convertTMDBCredit converts a TMDB movie or TV credit to our model
+func convertTMDBCredit(credit TMDBCreditResult) models.PersonCredit {
+	title := credit.Title
+	releaseDate := credit.ReleaseDate
+	if credit.MediaType == "tv" {
+		title = credit.Name
+		releaseDate = credit.FirstAirDate
+	}
+
+	return models.PersonCredit{
+		ID:           credit.ID,
+		MediaType:    credit.MediaType,
+		Title:        title,
+		ReleaseDate:  releaseDate,
+		PosterPath:   credit.PosterPath,
+		Character:    credit.Character,
+		Job:          credit.Job,
+		Department:   credit.Department,
+		EpisodeCount: credit.EpisodeCount,
+		GenreIDs:     credit.GenreIDs,
+		VoteAverage:  credit.VoteAverage,
+		VoteCount:    credit.VoteCount,
+		Popularity:   credit.Popularity,
+	}
+}
+
+// convertTMDBImage converts a TMDB image to our model
+func convertTMDBImage(image TMDBImage) models.Image {
+	return models.Image{
+		FilePath:    image.FilePath,
+		Width:       image.Width,
+		Height:      image.Height,
+		AspectRatio: image.AspectRatio,
+		VoteAverage: image.VoteAverage,
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// TMDBProvider represents a watch provider in TMDB responses
+type TMDBProvider struct {
+	ProviderID      int    `json:"provider_id"`
+	ProviderName    string `json:"provider_name"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+// TMDBWatchProvidersResponse represents TMDB watch/providers response for a title
+type TMDBWatchProvidersResponse struct {
+	ID      int `json:"id"`
+	Results map[string]struct {
+		Link     string         `json:"link"`
+		Flatrate []TMDBProvider `json:"flatrate"`
+		Rent     []TMDBProvider `json:"rent"`
+		Buy      []TMDBProvider `json:"buy"`
+		Free     []TMDBProvider `json:"free"`
+		Ads      []TMDBProvider `json:"ads"`
+	} `json:"results"`
+}
+
+// TMDBProviderListResponse represents TMDB watch provider list response
+type TMDBProviderListResponse struct {
+	Results []struct {
+		TMDBProvider
+		DisplayPriorities map[string]int `json:"display_priorities"`
+	} `json:"results"`
+}
+
+// GetWatchProviders retrieves where a movie or TV show can be streamed, rented
+// or bought in every country TMDB knows about
+func (s *TMDBService) GetWatchProviders(ctx context.Context, mediaType string, id int) (*models.WatchProviders, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_watch_providers", mediaType, id)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if providers, ok := cached.(*models.WatchProviders); ok {
+			return providers, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/%s/%d/watch/providers", s.config.BaseURL, mediaType, id)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch providers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbResp TMDBWatchProvidersResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	providers := &models.WatchProviders{
+		ID:        id,
+		MediaType: mediaType,
+		Results:   make(map[string]models.RegionProviders, len(tmdbResp.Results)),
+	}
+	for region, result := range tmdbResp.Results {
+		providers.Results[region] = models.RegionProviders{
+			Region:   region,
+			Link:     result.Link,
+			Flatrate: convertTMDBProviders(result.Flatrate),
+			Rent:     convertTMDBProviders(result.Rent),
+			Buy:      convertTMDBProviders(result.Buy),
+			Free:     convertTMDBProviders(result.Free),
+			Ads:      convertTMDBProviders(result.Ads),
+		}
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, providers, config.AppConfig.Cache.TTL)
+
+	return providers, nil
+}
+
+// GetProviders retrieves the watch providers available in a region
+func (s *TMDBService) GetProviders(ctx context.Context, mediaType string, region string) ([]models.Provider, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_providers", mediaType, region)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if providers, ok := cached.([]models.Provider); ok {
+			return providers, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/watch/providers/%s", s.config.BaseURL, mediaType)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("language", "en-US")
+	params.Set("watch_region", region)
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get providers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbResp TMDBProviderListResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model, ordered by their priority in the region
+	providers := make([]models.Provider, len(tmdbResp.Results))
+	for i, result := range tmdbResp.Results {
+		providers[i] = convertTMDBProvider(result.TMDBProvider)
+		if priority, exists := result.DisplayPriorities[region]; exists {
+			providers[i].DisplayPriority = priority
+		}
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].DisplayPriority < providers[j].DisplayPriority
+	})
+
+	// Cache the result (provider catalogs don't change often)
+	s.cache.Set(cacheKey, providers, 24*time.Hour)
+
+	return providers, nil
+}
+
+// DiscoverMedia discovers movies or TV shows matching a filter. When provider
+// IDs are set, only titles included with one of those subscriptions in the
+// filter's region are returned.
+func (s *TMDBService) DiscoverMedia(ctx context.Context, filter models.DiscoverFilter) (*models.MovieSearchResult, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_discover", filter.MediaType, filter.GenreIDs, filter.SortBy, filter.Region, filter.ProviderIDs, filter.Page)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if result, ok := cached.(*models.MovieSearchResult); ok {
+			return result, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/discover/%s", s.config.BaseURL, filter.MediaType)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("page", strconv.Itoa(filter.Page))
+	params.Set("sort_by", filter.SortBy)
+	params.Set("language", "en-US")
+	params.Set("include_adult", "false")
+	if len(filter.GenreIDs) > 0 {
+		params.Set("with_genres", joinInts(filter.GenreIDs, ","))
+	}
+	if filter.Region != "" {
+		params.Set("watch_region", filter.Region)
+	}
+	if len(filter.ProviderIDs) > 0 {
+		// A pipe means "any of" these providers
+		params.Set("with_watch_providers", joinInts(filter.ProviderIDs, "|"))
+		params.Set("with_watch_monetization_types", "flatrate|free|ads")
+	}
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	result := &models.MovieSearchResult{}
+	if filter.MediaType == models.MediaTypeTV {
+		var tvResp TMDBTVListResponse
+		if err := json.Unmarshal(body, &tvResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		result.Page, result.TotalPages, result.TotalResults = tvResp.Page, tvResp.TotalPages, tvResp.TotalResults
+		result.Results = make([]models.Media, len(tvResp.Results))
+		for i, tv := range tvResp.Results {
+			result.Results[i] = s.convertTMDBTVResult(tv)
+		}
+	} else {
+		var tmdbResp TMDBSearchResponse
+		if err := json.Unmarshal(body, &tmdbResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		result.Page, result.TotalPages, result.TotalResults = tmdbResp.Page, tmdbResp.TotalPages, tmdbResp.TotalResults
+		result.Results = make([]models.Media, len(tmdbResp.Results))
+		for i, tmdbMovie := range tmdbResp.Results {
+			result.Results[i] = *s.convertTMDBMovie(tmdbMovie)
+		}
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, result, config.AppConfig.Cache.SearchTTL)
+
+	return result, nil
+}
+
+// convertTMDBProviders converts a list of TMDB providers to our model
+func convertTMDBProviders(tmdbProviders []TMDBProvider) []models.Provider {
+	providers := make([]models.Provider, len(tmdbProviders))
+	for i, provider := range tmdbProviders {
+		providers[i] = convertTMDBProvider(provider)
+	}
+	return providers
+}
+
+// convertTMDBProvider converts a TMDB provider to our model
+func convertTMDBProvider(provider TMDBProvider) models.Provider {
+	return models.Provider{
+		ID:              provider.ProviderID,
+		Name:            provider.ProviderName,
+		LogoPath:        provider.LogoPath,
+		DisplayPriority: provider.DisplayPriority,
+	}
+}
+
+// joinInts joins integers with a separator
+func joinInts(values []int, sep string) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, sep)
+}
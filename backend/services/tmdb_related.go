@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// Kinds of related titles TMDB offers for a movie or TV show
+const (
+	RelatedRecommendations = "recommendations"
+	RelatedSimilar         = "similar"
+)
+
+// GetRelatedMedia retrieves a page of titles related to a movie or TV show.
+// Recommendations come from what TMDB users watch together; similar titles
+// share genres and keywords.
+func (s *TMDBService) GetRelatedMedia(ctx context.Context, mediaType string, id int, kind string, page int) ([]models.Media, error) {
+	if kind != RelatedRecommendations && kind != RelatedSimilar {
+		return nil, fmt.Errorf("unknown related kind %q", kind)
+	}
+
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_related", mediaType, id, kind, page)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if results, ok := cached.([]models.Media); ok {
+			return results, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/%s/%d/%s", s.config.BaseURL, mediaType, id, kind)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var results []models.Media
+	if mediaType == models.MediaTypeTV {
+		var tvResp TMDBTVListResponse
+		if err := json.Unmarshal(body, &tvResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		results = make([]models.Media, len(tvResp.Results))
+		for i, tv := range tvResp.Results {
+			results[i] = s.convertTMDBTVResult(tv)
+		}
+	} else {
+		var tmdbResp TMDBSearchResponse
+		if err := json.Unmarshal(body, &tmdbResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		results = make([]models.Media, len(tmdbResp.Results))
+		for i, tmdbMovie := range tmdbResp.Results {
+			results[i] = *s.convertTMDBMovie(tmdbMovie)
+		}
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, results, config.AppConfig.Cache.TTL)
+
+	return results, nil
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// TMDBReleaseDatesResponse represents TMDB release_dates response
+type TMDBReleaseDatesResponse struct {
+	ID      int `json:"id"`
+	Results []struct {
+		Region       string `json:"iso_3166_1"`
+		ReleaseDates []struct {
+			Certification string `json:"certification"`
+			Note          string `json:"note"`
+			ReleaseDate   string `json:"release_date"`
+			Type          int    `json:"type"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
+
+// GetReleaseDates retrieves a movie's theatrical, digital and physical release
+// dates in every country, keyed by ISO 3166-1 country code
+func (s *TMDBService) GetReleaseDates(ctx context.Context, movieID int) (map[string][]models.ReleaseDate, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_release_dates", movieID)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if releases, ok := cached.(map[string][]models.ReleaseDate); ok {
+			return releases, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/movie/%d/release_dates", s.config.BaseURL, movieID)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release dates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbResp TMDBReleaseDatesResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	releases := make(map[string][]models.ReleaseDate, len(tmdbResp.Results))
+	for _, result := range tmdbResp.Results {
+		for _, release := range result.ReleaseDates {
+			// TMDB sends full timestamps; only the day matters
+			date := release.ReleaseDate
+			if len(date) > 10 {
+				date = date[:10]
+			}
+			releases[result.Region] = append(releases[result.Region], models.ReleaseDate{
+				Type:          release.Type,
+				Date:          date,
+				Certification: release.Certification,
+				Note:          release.Note,
+			})
+		}
+	}
+
+	// Cache the result (release schedules change rarely)
+	s.cache.Set(cacheKey, releases, 24*time.Hour)
+
+	return releases, nil
+}
@@ -16,30 +16,38 @@ import (
 
 // TMDBService handles all TMDB API interactions
 type TMDBService struct {
-	client *utils.HTTPClient
-	cache  *utils.Cache
-	config *config.TMDBConfig
+	client    *utils.HTTPClient
+	cache     *utils.Cache
+	config    *config.TMDBConfig
+	observers []MediaObserver
+}
+
+// MediaObserver is notified about titles fetched from TMDB so that local
+// indexes can learn from what users have already seen
+type MediaObserver interface {
+	ObserveMedia(media []models.Media)
 }
 
 // TMDBMovieResponse represents TMDB movie response
 type TMDBMovieResponse struct {
-	ID            int     `json:"id"`
-	Title         string  `json:"title"`
-	OriginalTitle string  `json:"original_title"`
-	Overview      string  `json:"overview"`
-	PosterPath    string  `json:"poster_path"`
-	BackdropPath  string  `json:"backdrop_path"`
-	ReleaseDate   string  `json:"release_date"`
-	Runtime       int     `json:"runtime"`
-	Status        string  `json:"status"`
-	Tagline       string  `json:"tagline"`
-	VoteAverage   float64 `json:"vote_average"`
-	VoteCount     int     `json:"vote_count"`
-	Popularity    float64 `json:"popularity"`
-	Adult         bool    `json:"adult"`
-	Video         bool    `json:"video"`
-	GenreIDs      []int   `json:"genre_ids"`
-	Genres        []struct {
+	ID               int     `json:"id"`
+	Title            string  `json:"title"`
+	OriginalTitle    string  `json:"original_title"`
+	OriginalLanguage string  `json:"original_language"`
+	Overview         string  `json:"overview"`
+	PosterPath       string  `json:"poster_path"`
+	BackdropPath     string  `json:"backdrop_path"`
+	ReleaseDate      string  `json:"release_date"`
+	Runtime          int     `json:"runtime"`
+	Status           string  `json:"status"`
+	Tagline          string  `json:"tagline"`
+	VoteAverage      float64 `json:"vote_average"`
+	VoteCount        int     `json:"vote_count"`
+	Popularity       float64 `json:"popularity"`
+	Adult            bool    `json:"adult"`
+	Video            bool    `json:"video"`
+	GenreIDs         []int   `json:"genre_ids"`
+	Genres           []struct {
 		ID   int    `json:"id"`
 		Name string `json:"name"`
 	} `json:"genres"`
@@ -53,6 +61,18 @@ type TMDBMovieResponse struct {
 		ISO6391 string `json:"iso_639_1"`
 		Name    string `json:"name"`
 	} `json:"spoken_languages"`
+	BelongsToCollection *struct {
+		ID           int    `json:"id"`
+		Name         string `json:"name"`
+		PosterPath   string `json:"poster_path"`
+		BackdropPath string `json:"backdrop_path"`
+	} `json:"belongs_to_collection"`
+	Keywords struct {
+		Keywords []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"keywords"`
+	} `json:"keywords"`
 }
 
 // TMDBCreditsResponse represents TMDB credits response
@@ -90,6 +110,31 @@ type TMDBTrendingResponse struct {
 	TotalResults int                 `json:"total_results"`
 }
 
+// TMDBTVResult represents a TV show entry in TMDB list responses
+type TMDBTVResult struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	OriginalLanguage string   `json:"original_language"`
+	Overview         string   `json:"overview"`
+	PosterPath       string   `json:"poster_path"`
+	BackdropPath     string   `json:"backdrop_path"`
+	FirstAirDate     string   `json:"first_air_date"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
+	Popularity       float64  `json:"popularity"`
+	GenreIDs         []int    `json:"genre_ids"`
+	OriginCountry    []string `json:"origin_country"`
+}
+
+// TMDBTVListResponse represents a page of TV shows from TMDB
+type TMDBTVListResponse struct {
+	Page         int            `json:"page"`
+	Results      []TMDBTVResult `json:"results"`
+	TotalPages   int            `json:"total_pages"`
+	TotalResults int            `json:"total_results"`
+}
+
 // TMDBGenreResponse represents TMDB genre response
 type TMDBGenreResponse struct {
 	Genres []struct {
@@ -107,12 +152,27 @@ func NewTMDBService() *TMDBService {
 	}
 }
 
+// AddObserver registers an observer for titles fetched from TMDB
+func (s *TMDBService) AddObserver(observer MediaObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+// notifyMedia passes fetched movies and TV shows to all observers
+func (s *TMDBService) notifyMedia(media []models.Media) {
+	if len(media) == 0 {
+		return
+	}
+	for _, observer := range s.observers {
+		observer.ObserveMedia(media)
+	}
+}
+
 // SearchMedia searches for movies and/or TV shows using TMDB API
 func (s *TMDBService) SearchMedia(ctx context.Context, query string, page int, perPage int, mediaType string, includeAdult bool) (*models.MovieSearchResult, error) {
 	if perPage <= 0 {
 		perPage = 10
 	}
-	var allResults []models.Movie
+	var allResults []models.Media
 
 	if mediaType == "movie" || mediaType == "all" {
 		// Search movies
@@ -130,9 +190,7 @@ func (s *TMDBService) SearchMedia(ctx context.Context, query string, page int, p
 			var tmdbResp TMDBSearchResponse
 			if err := json.Unmarshal(body, &tmdbResp); err == nil {
 				for _, tmdbMovie := range tmdbResp.Results {
-					movie := *s.convertTMDBMovie(tmdbMovie)
-					movie.MediaType = "movie"
-					allResults = append(allResults, movie)
+					allResults = append(allResults, *s.convertTMDBMovie(tmdbMovie))
 				}
 			}
 			resp.Body.Close()
@@ -151,49 +209,18 @@ func (s *TMDBService) SearchMedia(ctx context.Context, query string, page int, p
 		resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
 		if err == nil {
 			body, _ := io.ReadAll(resp.Body)
-			var tvResp struct {
-				Page    int `json:"page"`
-				Results []struct {
-					ID            int      `json:"id"`
-					Name          string   `json:"name"`
-					OriginalName  string   `json:"original_name"`
-					Overview      string   `json:"overview"`
-					PosterPath    string   `json:"poster_path"`
-					BackdropPath  string   `json:"backdrop_path"`
-					FirstAirDate  string   `json:"first_air_date"`
-					VoteAverage   float64  `json:"vote_average"`
-					VoteCount     int      `json:"vote_count"`
-					Popularity    float64  `json:"popularity"`
-					GenreIDs      []int    `json:"genre_ids"`
-					OriginCountry []string `json:"origin_country"`
-					// Add more fields as needed
-				} `json:"results"`
-				TotalPages   int `json:"total_pages"`
-				TotalResults int `json:"total_results"`
-			}
+			var tvResp TMDBTVListResponse
 			if err := json.Unmarshal(body, &tvResp); err == nil {
 				for _, tv := range tvResp.Results {
-					movie := models.Movie{
-						ID:            tv.ID,
-						Title:         tv.Name,
-						OriginalTitle: tv.OriginalName,
-						Overview:      tv.Overview,
-						PosterPath:    tv.PosterPath,
-						BackdropPath:  tv.BackdropPath,
-						ReleaseDate:   tv.FirstAirDate,
-						VoteAverage:   tv.VoteAverage,
-						VoteCount:     tv.VoteCount,
-						Popularity:    tv.Popularity,
-						GenreIDs:      tv.GenreIDs,
-						MediaType:     "tv",
-					}
-					allResults = append(allResults, movie)
+					allResults = append(allResults, s.convertTMDBTVResult(tv))
 				}
 			}
 			resp.Body.Close()
 		}
 	}
 
+	s.notifyMedia(allResults)
+
 	// Manual pagination
 	totalResults := len(allResults)
 	totalPages := (totalResults + perPage - 1) / perPage
@@ -205,7 +232,7 @@ func (s *TMDBService) SearchMedia(ctx context.Context, query string, page int, p
 	if end > totalResults {
 		end = totalResults
 	}
-	paged := []models.Movie{}
+	paged := []models.Media{}
 	if start < end {
 		paged = allResults[start:end]
 	}
@@ -221,13 +248,13 @@ func (s *TMDBService) SearchMedia(ctx context.Context, query string, page int, p
 }
 
 // GetMovieDetails retrieves detailed movie information
-func (s *TMDBService) GetMovieDetails(ctx context.Context, movieID int) (*models.Movie, error) {
+func (s *TMDBService) GetMovieDetails(ctx context.Context, movieID int) (*models.Media, error) {
 	// Generate cache key
 	cacheKey := utils.GenerateCacheKey("tmdb_movie", movieID)
 
 	// Check cache first
 	if cached, exists := s.cache.Get(cacheKey); exists {
-		if movie, ok := cached.(*models.Movie); ok {
+		if movie, ok := cached.(*models.Media); ok {
 			return movie, nil
 		}
 	}
@@ -236,7 +263,7 @@ func (s *TMDBService) GetMovieDetails(ctx context.Context, movieID int) (*models
 	baseURL := fmt.Sprintf("%s/movie/%d", s.config.BaseURL, movieID)
 	params := url.Values{}
 	params.Set("api_key", s.config.APIKey)
-	params.Set("append_to_response", "credits")
+	params.Set("append_to_response", "credits,keywords")
 	params.Set("language", "en-US")
 
 	// Make request
@@ -281,6 +308,8 @@ func (s *TMDBService) GetMovieDetails(ctx context.Context, movieID int) (*models
 	// Cache the result
 	s.cache.Set(cacheKey, movie, config.AppConfig.Cache.TTL)
 
+	s.notifyMedia([]models.Media{*movie})
+
 	return movie, nil
 }
 
@@ -385,7 +414,7 @@ func (s *TMDBService) GetTrendingMedia(ctx context.Context, timeframe string, pa
 		timeframe = "day"
 	}
 
-	var allResults []models.Movie
+	var allResults []models.Media
 
 	if mediaType == "movie" || mediaType == "all" {
 		baseURL := fmt.Sprintf("%s/trending/movie/%s", s.config.BaseURL, timeframe)
@@ -400,9 +429,7 @@ func (s *TMDBService) GetTrendingMedia(ctx context.Context, timeframe string, pa
 			var tmdbResp TMDBTrendingResponse
 			if err := json.Unmarshal(body, &tmdbResp); err == nil {
 				for _, tmdbMovie := range tmdbResp.Results {
-					movie := *s.convertTMDBMovie(tmdbMovie)
-					movie.MediaType = "movie"
-					allResults = append(allResults, movie)
+					allResults = append(allResults, *s.convertTMDBMovie(tmdbMovie))
 				}
 			}
 			resp.Body.Close()
@@ -419,42 +446,10 @@ func (s *TMDBService) GetTrendingMedia(ctx context.Context, timeframe string, pa
 		resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
 		if err == nil {
 			body, _ := io.ReadAll(resp.Body)
-			var tvResp struct {
-				Page    int `json:"page"`
-				Results []struct {
-					ID            int      `json:"id"`
-					Name          string   `json:"name"`
-					OriginalName  string   `json:"original_name"`
-					Overview      string   `json:"overview"`
-					PosterPath    string   `json:"poster_path"`
-					BackdropPath  string   `json:"backdrop_path"`
-					FirstAirDate  string   `json:"first_air_date"`
-					VoteAverage   float64  `json:"vote_average"`
-					VoteCount     int      `json:"vote_count"`
-					Popularity    float64  `json:"popularity"`
-					GenreIDs      []int    `json:"genre_ids"`
-					OriginCountry []string `json:"origin_country"`
-				} `json:"results"`
-				TotalPages   int `json:"total_pages"`
-				TotalResults int `json:"total_results"`
-			}
+			var tvResp TMDBTVListResponse
 			if err := json.Unmarshal(body, &tvResp); err == nil {
 				for _, tv := range tvResp.Results {
-					movie := models.Movie{
-						ID:            tv.ID,
-						Title:         tv.Name,
-						OriginalTitle: tv.OriginalName,
-						Overview:      tv.Overview,
-						PosterPath:    tv.PosterPath,
-						BackdropPath:  tv.BackdropPath,
-						ReleaseDate:   tv.FirstAirDate,
-						VoteAverage:   tv.VoteAverage,
-						VoteCount:     tv.VoteCount,
-						Popularity:    tv.Popularity,
-						GenreIDs:      tv.GenreIDs,
-						MediaType:     "tv",
-					}
-					allResults = append(allResults, movie)
+					allResults = append(allResults, s.convertTMDBTVResult(tv))
 				}
 			}
 			resp.Body.Close()
@@ -471,7 +466,7 @@ func (s *TMDBService) GetTrendingMedia(ctx context.Context, timeframe string, pa
 	if end > totalResults {
 		end = totalResults
 	}
-	paged := []models.Movie{}
+	paged := []models.Media{}
 	if start < end {
 		paged = allResults[start:end]
 	}
@@ -486,6 +481,67 @@ func (s *TMDBService) GetTrendingMedia(ctx context.Context, timeframe string, pa
 	return result, nil
 }
 
+// GetPopularMedia retrieves the current popular movies or TV shows
+func (s *TMDBService) GetPopularMedia(ctx context.Context, mediaType string, page int) (*models.MovieSearchResult, error) {
+	if mediaType != "tv" {
+		mediaType = "movie"
+	}
+	return s.GetMediaList(ctx, mediaType, ListPopular, "", page)
+}
+
+// GetTrendingPeople retrieves trending people
+func (s *TMDBService) GetTrendingPeople(ctx context.Context, timeframe string) ([]models.Person, error) {
+	if timeframe != "day" && timeframe != "week" {
+		timeframe = "day"
+	}
+
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_trending_people", timeframe)
+
+	// Check cache first
+	if cached, exists := s.cacheGet(ctx, cacheKey); exists {
+		if people, ok := cached.([]models.Person); ok {
+			return people, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/trending/person/%s", s.config.BaseURL, timeframe)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending people: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbResp TMDBPersonListResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to our model
+	people := make([]models.Person, len(tmdbResp.Results))
+	for i, person := range tmdbResp.Results {
+		people[i] = s.convertTMDBPerson(person)
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, people, config.AppConfig.Cache.TrendingTTL)
+
+	return people, nil
+}
+
 // GetMoviesByGenre retrieves movies by genre
 func (s *TMDBService) GetMoviesByGenre(ctx context.Context, genreID int, page int, sortBy string) (*models.MovieSearchResult, error) {
 	// Generate cache key
@@ -528,7 +584,7 @@ func (s *TMDBService) GetMoviesByGenre(ctx context.Context, genreID int, page in
 	}
 
 	// Convert to our model
-	movies := make([]models.Movie, len(tmdbResp.Results))
+	movies := make([]models.Media, len(tmdbResp.Results))
 	for i, tmdbMovie := range tmdbResp.Results {
 		movies[i] = *s.convertTMDBMovie(tmdbMovie)
 	}
@@ -546,20 +602,25 @@ func (s *TMDBService) GetMoviesByGenre(ctx context.Context, genreID int, page in
 	return result, nil
 }
 
-// GetGenres retrieves all available genres
+// GetGenres retrieves all available movie genres
 func (s *TMDBService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	return s.getGenreList(ctx, "movie")
+}
+
+// getGenreList retrieves the genre list for movies or TV shows
+func (s *TMDBService) getGenreList(ctx context.Context, mediaType string) ([]models.Genre, error) {
 	// Generate cache key
-	cacheKey := utils.GenerateCacheKey("tmdb_genres")
+	cacheKey := utils.GenerateCacheKey("tmdb_genres", mediaType)
 
 	// Check cache first
-	if cached, exists := s.cache.Get(cacheKey); exists {
+	if cached, exists := s.cacheGet(ctx, cacheKey); exists {
 		if genres, ok := cached.([]models.Genre); ok {
 			return genres, nil
 		}
 	}
 
 	// Build URL
-	baseURL := s.config.BaseURL + "/genre/movie/list"
+	baseURL := fmt.Sprintf("%s/genre/%s/list", s.config.BaseURL, mediaType)
 	params := url.Values{}
 	params.Set("api_key", s.config.APIKey)
 	params.Set("language", "en-US")
@@ -599,27 +660,30 @@ func (s *TMDBService) GetGenres(ctx context.Context) ([]models.Genre, error) {
 }
 
 // convertTMDBMovie converts TMDB movie response to our model
-func (s *TMDBService) convertTMDBMovie(tmdbMovie TMDBMovieResponse) *models.Movie {
-	movie := &models.Movie{
-		ID:            tmdbMovie.ID,
-		Title:         tmdbMovie.Title,
-		OriginalTitle: tmdbMovie.OriginalTitle,
-		Overview:      tmdbMovie.Overview,
-		PosterPath:    tmdbMovie.PosterPath,
-		BackdropPath:  tmdbMovie.BackdropPath,
-		ReleaseDate:   tmdbMovie.ReleaseDate,
-		Runtime:       tmdbMovie.Runtime,
-		Status:        tmdbMovie.Status,
-		Tagline:       tmdbMovie.Tagline,
-		VoteAverage:   tmdbMovie.VoteAverage,
-		VoteCount:     tmdbMovie.VoteCount,
-		Popularity:    tmdbMovie.Popularity,
-		Adult:         tmdbMovie.Adult,
-		Video:         tmdbMovie.Video,
-		GenreIDs:      tmdbMovie.GenreIDs,
-		MediaType:     "movie",
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+func (s *TMDBService) convertTMDBMovie(tmdbMovie TMDBMovieResponse) *models.Media {
+	movie := &models.Media{
+		ID:               tmdbMovie.ID,
+		Title:            tmdbMovie.Title,
+		OriginalTitle:    tmdbMovie.OriginalTitle,
+		OriginalLanguage: tmdbMovie.OriginalLanguage,
+		Overview:         tmdbMovie.Overview,
+		PosterPath:       tmdbMovie.PosterPath,
+		BackdropPath:     tmdbMovie.BackdropPath,
+		ReleaseDate:      tmdbMovie.ReleaseDate,
+		Runtime:          tmdbMovie.Runtime,
+		Status:           tmdbMovie.Status,
+		Tagline:          tmdbMovie.Tagline,
+		VoteAverage:      tmdbMovie.VoteAverage,
+		VoteCount:        tmdbMovie.VoteCount,
+		Popularity:       tmdbMovie.Popularity,
+		Adult:            tmdbMovie.Adult,
+		GenreIDs:         tmdbMovie.GenreIDs,
+		MediaType:        models.MediaTypeMovie,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+		MovieDetails: &models.MovieDetails{
+			Video: tmdbMovie.Video,
+		},
 	}
 
 	// Convert genres
@@ -657,6 +721,21 @@ func (s *TMDBService) convertTMDBMovie(tmdbMovie TMDBMovieResponse) *models.Movi
 		}
 	}
 
+	// Convert collection
+	if tmdbMovie.BelongsToCollection != nil {
+		movie.BelongsToCollection = &models.Collection{
+			ID:           tmdbMovie.BelongsToCollection.ID,
+			Name:         tmdbMovie.BelongsToCollection.Name,
+			PosterPath:   tmdbMovie.BelongsToCollection.PosterPath,
+			BackdropPath: tmdbMovie.BelongsToCollection.BackdropPath,
+		}
+	}
+
+	// Convert keywords
+	for _, keyword := range tmdbMovie.Keywords.Keywords {
+		movie.Keywords = append(movie.Keywords, models.Keyword{ID: keyword.ID, Name: keyword.Name})
+	}
+
 	// Initialize ratings
 	movie.Ratings = models.Ratings{
 		TMDB: tmdbMovie.VoteAverage,
@@ -668,102 +747,28 @@ func (s *TMDBService) convertTMDBMovie(tmdbMovie TMDBMovieResponse) *models.Movi
 	return movie
 }
 
+// convertTMDBTVResult converts a TMDB TV list entry to our media model
+func (s *TMDBService) convertTMDBTVResult(tv TMDBTVResult) models.Media {
+	return models.Media{
+		ID:               tv.ID,
+		Title:            tv.Name,
+		OriginalTitle:    tv.OriginalName,
+		OriginalLanguage: tv.OriginalLanguage,
+		Overview:         tv.Overview,
+		PosterPath:       tv.PosterPath,
+		BackdropPath:     tv.BackdropPath,
+		ReleaseDate:      tv.FirstAirDate,
+		VoteAverage:      tv.VoteAverage,
+		VoteCount:        tv.VoteCount,
+		Popularity:       tv.Popularity,
+		GenreIDs:         tv.GenreIDs,
+		MediaType:        models.MediaTypeTV,
+	}
+}
+
 // Close closes the service and cleans up resources
 func (s *TMDBService) Close() {
 	if s.client != nil {
 		s.client.Close()
 	}
 }
-
-// GetTVDetails fetches TV show details from TMDB
-func (s *TMDBService) GetTVDetails(ctx context.Context, tvID int) (*models.TV, error) {
-	baseURL := s.config.BaseURL + "/tv/" + strconv.Itoa(tvID)
-	params := url.Values{}
-	params.Set("api_key", s.config.APIKey)
-	params.Set("language", "en-US")
-
-	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var tmdbTV struct {
-		ID               int     `json:"id"`
-		Name             string  `json:"name"`
-		OriginalName     string  `json:"original_name"`
-		Overview         string  `json:"overview"`
-		PosterPath       string  `json:"poster_path"`
-		BackdropPath     string  `json:"backdrop_path"`
-		FirstAirDate     string  `json:"first_air_date"`
-		LastAirDate      string  `json:"last_air_date"`
-		NumberOfSeasons  int     `json:"number_of_seasons"`
-		NumberOfEpisodes int     `json:"number_of_episodes"`
-		Status           string  `json:"status"`
-		Tagline          string  `json:"tagline"`
-		VoteAverage      float64 `json:"vote_average"`
-		VoteCount        int     `json:"vote_count"`
-		Popularity       float64 `json:"popularity"`
-		Genres           []struct {
-			ID   int    `json:"id"`
-			Name string `json:"name"`
-		} `json:"genres"`
-		ProductionCompanies []struct {
-			ID            int    `json:"id"`
-			Name          string `json:"name"`
-			LogoPath      string `json:"logo_path"`
-			OriginCountry string `json:"origin_country"`
-		} `json:"production_companies"`
-		SpokenLanguages []struct {
-			ISO6391 string `json:"iso_639_1"`
-			Name    string `json:"name"`
-		} `json:"spoken_languages"`
-	}
-	if err := json.Unmarshal(body, &tmdbTV); err != nil {
-		return nil, err
-	}
-
-	// Convert to models.TV
-	genres := make([]models.Genre, len(tmdbTV.Genres))
-	for i, g := range tmdbTV.Genres {
-		genres[i] = models.Genre{ID: g.ID, Name: g.Name}
-	}
-	prodCompanies := make([]models.ProductionCompany, len(tmdbTV.ProductionCompanies))
-	for i, pc := range tmdbTV.ProductionCompanies {
-		prodCompanies[i] = models.ProductionCompany{ID: pc.ID, Name: pc.Name, LogoPath: pc.LogoPath, OriginCountry: pc.OriginCountry}
-	}
-	languages := make([]models.SpokenLanguage, len(tmdbTV.SpokenLanguages))
-	for i, l := range tmdbTV.SpokenLanguages {
-		languages[i] = models.SpokenLanguage{ISO6391: l.ISO6391, Name: l.Name}
-	}
-
-	tv := &models.TV{
-		ID:                  tmdbTV.ID,
-		Name:                tmdbTV.Name,
-		OriginalName:        tmdbTV.OriginalName,
-		Overview:            tmdbTV.Overview,
-		PosterPath:          tmdbTV.PosterPath,
-		BackdropPath:        tmdbTV.BackdropPath,
-		FirstAirDate:        tmdbTV.FirstAirDate,
-		LastAirDate:         tmdbTV.LastAirDate,
-		NumberOfSeasons:     tmdbTV.NumberOfSeasons,
-		NumberOfEpisodes:    tmdbTV.NumberOfEpisodes,
-		Status:              tmdbTV.Status,
-		Tagline:             tmdbTV.Tagline,
-		VoteAverage:         tmdbTV.VoteAverage,
-		VoteCount:           tmdbTV.VoteCount,
-		Popularity:          tmdbTV.Popularity,
-		Genres:              genres,
-		ProductionCompanies: prodCompanies,
-		SpokenLanguages:     languages,
-		MediaType:           "tv",
-		CreatedAt:           time.Now(),
-		UpdatedAt:           time.Now(),
-	}
-	return tv, nil
-}
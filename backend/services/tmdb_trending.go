@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// trendingPoolPages is how many pages of trending results (20 per page) are
+// pulled per media type when filtering trending titles by genre
+const trendingPoolPages = 5
+
+// trendingSorts are the orders GetTrendingByGenre accepts besides trending
+// order, named like TMDB's discover sort_by values. Titles without a release
+// date sort last.
+var trendingSorts = map[string]func(a, b models.Media) bool{
+	"popularity.desc":   func(a, b models.Media) bool { return a.Popularity > b.Popularity },
+	"popularity.asc":    func(a, b models.Media) bool { return a.Popularity < b.Popularity },
+	"vote_average.desc": func(a, b models.Media) bool { return a.VoteAverage > b.VoteAverage },
+	"vote_average.asc":  func(a, b models.Media) bool { return a.VoteAverage < b.VoteAverage },
+	"release_date.desc": func(a, b models.Media) bool {
+		return (b.ReleaseDate == "" && a.ReleaseDate != "") || (a.ReleaseDate > b.ReleaseDate && b.ReleaseDate != "")
+	},
+	"release_date.asc": func(a, b models.Media) bool {
+		return (b.ReleaseDate == "" && a.ReleaseDate != "") || (a.ReleaseDate < b.ReleaseDate && a.ReleaseDate != "")
+	},
+}
+
+// IsTrendingSort reports whether sortBy is a supported order for trending
+// titles by genre
+func IsTrendingSort(sortBy string) bool {
+	_, ok := trendingSorts[sortBy]
+	return ok
+}
+
+// GetTrendingPool retrieves the top trending movies or TV shows across
+// several pages, in trending order
+func (s *TMDBService) GetTrendingPool(ctx context.Context, mediaType string, timeframe string) ([]models.Media, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_trending_pool", mediaType, timeframe)
+
+	// Check cache first
+	if cached, exists := s.cacheGet(ctx, cacheKey); exists {
+		if pool, ok := cached.([]models.Media); ok {
+			return pool, nil
+		}
+	}
+
+	pool := []models.Media{}
+	seen := make(map[int]bool)
+	for page := 1; page <= trendingPoolPages; page++ {
+		results, totalPages, err := s.getTrendingPage(ctx, mediaType, timeframe, page)
+		if err != nil {
+			// Keep what we have if a later page fails
+			if page > 1 {
+				break
+			}
+			return nil, err
+		}
+		for _, media := range results {
+			// Titles can shift between pages while we're paging
+			if !seen[media.ID] {
+				seen[media.ID] = true
+				pool = append(pool, media)
+			}
+		}
+		if page >= totalPages {
+			break
+		}
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, pool, config.AppConfig.Cache.TrendingTTL)
+
+	return pool, nil
+}
+
+// getTrendingPage retrieves a single page of trending movies or TV shows
+func (s *TMDBService) getTrendingPage(ctx context.Context, mediaType string, timeframe string, page int) ([]models.Media, int, error) {
+	// Build URL
+	baseURL := fmt.Sprintf("%s/trending/%s/%s", s.config.BaseURL, mediaType, timeframe)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get trending %s: %w", mediaType, err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	if mediaType == models.MediaTypeTV {
+		var tvResp TMDBTVListResponse
+		if err := json.Unmarshal(body, &tvResp); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+		}
+		results := make([]models.Media, len(tvResp.Results))
+		for i, tv := range tvResp.Results {
+			results[i] = s.convertTMDBTVResult(tv)
+		}
+		return results, tvResp.TotalPages, nil
+	}
+
+	var tmdbResp TMDBTrendingResponse
+	if err := json.Unmarshal(body, &tmdbResp); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	results := make([]models.Media, len(tmdbResp.Results))
+	for i, tmdbMovie := range tmdbResp.Results {
+		results[i] = *s.convertTMDBMovie(tmdbMovie)
+	}
+	return results, tmdbResp.TotalPages, nil
+}
+
+// GetTrendingByGenre retrieves trending titles in the given genres, ranked by
+// their trending position. Movies and TV shows at the same position are
+// ordered by popularity.
+func (s *TMDBService) GetTrendingByGenre(ctx context.Context, filter models.TrendingGenreFilter) ([]models.Media, error) {
+	mediaTypes := []string{models.MediaTypeMovie, models.MediaTypeTV}
+	if filter.MediaType == models.MediaTypeMovie || filter.MediaType == models.MediaTypeTV {
+		mediaTypes = []string{filter.MediaType}
+	}
+
+	type rankedMedia struct {
+		media models.Media
+		rank  int
+	}
+	var ranked []rankedMedia
+	for _, mediaType := range mediaTypes {
+		pool, err := s.GetTrendingPool(ctx, mediaType, filter.Timeframe)
+		if err != nil {
+			return nil, err
+		}
+		for i, media := range pool {
+			if matchesGenres(media.GenreIDs, filter.GenreIDs, filter.MatchAll) {
+				ranked = append(ranked, rankedMedia{media: media, rank: i})
+			}
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].rank != ranked[j].rank {
+			return ranked[i].rank < ranked[j].rank
+		}
+		return ranked[i].media.Popularity > ranked[j].media.Popularity
+	})
+
+	results := make([]models.Media, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.media
+	}
+
+	// Reorder if asked, keeping trending order between ties
+	if less, ok := trendingSorts[filter.SortBy]; ok {
+		sort.SliceStable(results, func(i, j int) bool {
+			return less(results[i], results[j])
+		})
+	}
+
+	return results, nil
+}
+
+// matchesGenres reports whether a title's genres include any (or all) of the
+// wanted genres
+func matchesGenres(genreIDs []int, wanted []int, matchAll bool) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+
+	has := make(map[int]bool, len(genreIDs))
+	for _, id := range genreIDs {
+		has[id] = true
+	}
+	for _, id := range wanted {
+		if has[id] && !matchAll {
+			return true
+		}
+		if !has[id] && matchAll {
+			return false
+		}
+	}
+
+	return matchAll
+}
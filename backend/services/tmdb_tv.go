@@ -0,0 +1,510 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// TMDBTVResponse represents TMDB TV show details response
+type TMDBTVResponse struct {
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	OriginalLanguage string   `json:"original_language"`
+	OriginCountry    []string `json:"origin_country"`
+	Overview         string   `json:"overview"`
+	PosterPath       string   `json:"poster_path"`
+	BackdropPath     string   `json:"backdrop_path"`
+	FirstAirDate     string   `json:"first_air_date"`
+	LastAirDate      string   `json:"last_air_date"`
+	NumberOfSeasons  int      `json:"number_of_seasons"`
+	NumberOfEpisodes int      `json:"number_of_episodes"`
+	EpisodeRunTime   []int    `json:"episode_run_time"`
+	InProduction     bool     `json:"in_production"`
+	Type             string   `json:"type"`
+	Status           string   `json:"status"`
+	Tagline          string   `json:"tagline"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
+	Popularity       float64  `json:"popularity"`
+	Genres           []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"genres"`
+	ProductionCompanies []struct {
+		ID            int    `json:"id"`
+		Name          string `json:"name"`
+		LogoPath      string `json:"logo_path"`
+		OriginCountry string `json:"origin_country"`
+	} `json:"production_companies"`
+	SpokenLanguages []struct {
+		ISO6391 string `json:"iso_639_1"`
+		Name    string `json:"name"`
+	} `json:"spoken_languages"`
+	Networks []struct {
+		ID            int    `json:"id"`
+		Name          string `json:"name"`
+		LogoPath      string `json:"logo_path"`
+		OriginCountry string `json:"origin_country"`
+	} `json:"networks"`
+	CreatedBy []struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		ProfilePath string `json:"profile_path"`
+	} `json:"created_by"`
+	Seasons []struct {
+		ID           int    `json:"id"`
+		SeasonNumber int    `json:"season_number"`
+		Name         string `json:"name"`
+		Overview     string `json:"overview"`
+		AirDate      string `json:"air_date"`
+		EpisodeCount int    `json:"episode_count"`
+		PosterPath   string `json:"poster_path"`
+	} `json:"seasons"`
+	LastEpisodeToAir *TMDBEpisodeResponse `json:"last_episode_to_air"`
+	NextEpisodeToAir *TMDBEpisodeResponse `json:"next_episode_to_air"`
+	AggregateCredits struct {
+		Cast []struct {
+			ID          int    `json:"id"`
+			Name        string `json:"name"`
+			ProfilePath string `json:"profile_path"`
+			Order       int    `json:"order"`
+			Roles       []struct {
+				Character    string `json:"character"`
+				EpisodeCount int    `json:"episode_count"`
+			} `json:"roles"`
+			TotalEpisodeCount int `json:"total_episode_count"`
+		} `json:"cast"`
+		Crew []struct {
+			ID          int    `json:"id"`
+			Name        string `json:"name"`
+			ProfilePath string `json:"profile_path"`
+			Department  string `json:"department"`
+			Jobs        []struct {
+				Job          string `json:"job"`
+				EpisodeCount int    `json:"episode_count"`
+			} `json:"jobs"`
+			TotalEpisodeCount int `json:"total_episode_count"`
+		} `json:"crew"`
+	} `json:"aggregate_credits"`
+	Videos struct {
+		Results []struct {
+			Key  string `json:"key"`
+			Site string `json:"site"`
+			Type string `json:"type"`
+		} `json:"results"`
+	} `json:"videos"`
+	Keywords struct {
+		Results []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"results"`
+	} `json:"keywords"`
+}
+
+// TMDBEpisodeResponse represents TMDB episode response
+type TMDBEpisodeResponse struct {
+	ID             int     `json:"id"`
+	ShowID         int     `json:"show_id"`
+	SeasonNumber   int     `json:"season_number"`
+	EpisodeNumber  int     `json:"episode_number"`
+	Name           string  `json:"name"`
+	Overview       string  `json:"overview"`
+	AirDate        string  `json:"air_date"`
+	Runtime        int     `json:"runtime"`
+	StillPath      string  `json:"still_path"`
+	VoteAverage    float64 `json:"vote_average"`
+	VoteCount      int     `json:"vote_count"`
+	ProductionCode string  `json:"production_code"`
+	GuestStars     []struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		Character   string `json:"character"`
+		ProfilePath string `json:"profile_path"`
+		Order       int    `json:"order"`
+	} `json:"guest_stars"`
+	Crew []struct {
+		ID          int    `json:"id"`
+		Name        string `json:"name"`
+		Job         string `json:"job"`
+		Department  string `json:"department"`
+		ProfilePath string `json:"profile_path"`
+	} `json:"crew"`
+	Images struct {
+		Stills []TMDBImage `json:"stills"`
+	} `json:"images"`
+}
+
+// TMDBSeasonResponse represents TMDB season response
+type TMDBSeasonResponse struct {
+	ID           int                   `json:"id"`
+	SeasonNumber int                   `json:"season_number"`
+	Name         string                `json:"name"`
+	Overview     string                `json:"overview"`
+	AirDate      string                `json:"air_date"`
+	PosterPath   string                `json:"poster_path"`
+	VoteAverage  float64               `json:"vote_average"`
+	Episodes     []TMDBEpisodeResponse `json:"episodes"`
+}
+
+// GetTVDetails fetches TV show details, aggregate credits, trailer and seasons from TMDB
+func (s *TMDBService) GetTVDetails(ctx context.Context, tvID int) (*models.Media, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_tv", tvID)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if tv, ok := cached.(*models.Media); ok {
+			return tv, nil
+		}
+	}
+
+	// Build URL
+	baseURL := s.config.BaseURL + "/tv/" + strconv.Itoa(tvID)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("append_to_response", "aggregate_credits,videos,keywords")
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV details: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbTV TMDBTVResponse
+	if err := json.Unmarshal(body, &tmdbTV); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if tmdbTV.ID == 0 {
+		return nil, fmt.Errorf("TV show %d not found", tvID)
+	}
+
+	// Convert to our model
+	tv := s.convertTMDBTV(tmdbTV)
+
+	// Cache the result
+	s.cache.Set(cacheKey, tv, config.AppConfig.Cache.TTL)
+
+	s.notifyMedia([]models.Media{*tv})
+
+	return tv, nil
+}
+
+// GetTVSeason retrieves a season of a TV show with all of its episodes
+func (s *TMDBService) GetTVSeason(ctx context.Context, tvID int, seasonNumber int) (*models.Season, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_tv_season", tvID, seasonNumber)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if season, ok := cached.(*models.Season); ok {
+			return season, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/tv/%d/season/%d", s.config.BaseURL, tvID, seasonNumber)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV season: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbSeason TMDBSeasonResponse
+	if err := json.Unmarshal(body, &tmdbSeason); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if tmdbSeason.ID == 0 {
+		return nil, fmt.Errorf("season %d of TV show %d not found", seasonNumber, tvID)
+	}
+
+	// Convert to our model
+	season := &models.Season{
+		ID:           tmdbSeason.ID,
+		ShowID:       tvID,
+		SeasonNumber: tmdbSeason.SeasonNumber,
+		Name:         tmdbSeason.Name,
+		Overview:     tmdbSeason.Overview,
+		AirDate:      tmdbSeason.AirDate,
+		PosterPath:   tmdbSeason.PosterPath,
+		VoteAverage:  tmdbSeason.VoteAverage,
+		Episodes:     make([]models.Episode, len(tmdbSeason.Episodes)),
+	}
+	for i, episode := range tmdbSeason.Episodes {
+		season.Episodes[i] = *convertTMDBEpisode(tvID, episode)
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, season, config.AppConfig.Cache.TTL)
+
+	return season, nil
+}
+
+// GetTVEpisode retrieves a single episode with guest stars, crew and stills
+func (s *TMDBService) GetTVEpisode(ctx context.Context, tvID int, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	// Generate cache key
+	cacheKey := utils.GenerateCacheKey("tmdb_tv_episode", tvID, seasonNumber, episodeNumber)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if episode, ok := cached.(*models.Episode); ok {
+			return episode, nil
+		}
+	}
+
+	// Build URL
+	baseURL := fmt.Sprintf("%s/tv/%d/season/%d/episode/%d", s.config.BaseURL, tvID, seasonNumber, episodeNumber)
+	params := url.Values{}
+	params.Set("api_key", s.config.APIKey)
+	params.Set("append_to_response", "images")
+	params.Set("language", "en-US")
+
+	// Make request
+	resp, err := s.client.Get(ctx, baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV episode: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var tmdbEpisode TMDBEpisodeResponse
+	if err := json.Unmarshal(body, &tmdbEpisode); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if tmdbEpisode.ID == 0 {
+		return nil, fmt.Errorf("episode S%02dE%02d of TV show %d not found", seasonNumber, episodeNumber, tvID)
+	}
+
+	// Convert to our model
+	episode := convertTMDBEpisode(tvID, tmdbEpisode)
+
+	// Cache the result
+	s.cache.Set(cacheKey, episode, config.AppConfig.Cache.TTL)
+
+	return episode, nil
+}
+
+// GetTVGenres retrieves all available TV genres
+func (s *TMDBService) GetTVGenres(ctx context.Context) ([]models.Genre, error) {
+	return s.getGenreList(ctx, "tv")
+}
+
+// convertTMDBTV converts TMDB TV details response to our model
+func (s *TMDBService) convertTMDBTV(tmdbTV TMDBTVResponse) *models.Media {
+	tv := &models.Media{
+		ID:               tmdbTV.ID,
+		Title:            tmdbTV.Name,
+		OriginalTitle:    tmdbTV.OriginalName,
+		OriginalLanguage: tmdbTV.OriginalLanguage,
+		Overview:         tmdbTV.Overview,
+		PosterPath:       tmdbTV.PosterPath,
+		BackdropPath:     tmdbTV.BackdropPath,
+		ReleaseDate:      tmdbTV.FirstAirDate,
+		Status:           tmdbTV.Status,
+		Tagline:          tmdbTV.Tagline,
+		VoteAverage:      tmdbTV.VoteAverage,
+		VoteCount:        tmdbTV.VoteCount,
+		Popularity:       tmdbTV.Popularity,
+		MediaType:        models.MediaTypeTV,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+		TVDetails: &models.TVDetails{
+			LastAirDate:      tmdbTV.LastAirDate,
+			NumberOfSeasons:  tmdbTV.NumberOfSeasons,
+			NumberOfEpisodes: tmdbTV.NumberOfEpisodes,
+			OriginCountry:    tmdbTV.OriginCountry,
+			Type:             tmdbTV.Type,
+			InProduction:     tmdbTV.InProduction,
+			EpisodeRunTime:   tmdbTV.EpisodeRunTime,
+		},
+	}
+
+	// Use the typical episode length as the runtime
+	if len(tmdbTV.EpisodeRunTime) > 0 {
+		tv.Runtime = tmdbTV.EpisodeRunTime[0]
+	}
+
+	// Convert genres
+	tv.Genres = make([]models.Genre, len(tmdbTV.Genres))
+	tv.GenreIDs = make([]int, len(tmdbTV.Genres))
+	for i, g := range tmdbTV.Genres {
+		tv.Genres[i] = models.Genre{ID: g.ID, Name: g.Name}
+		tv.GenreIDs[i] = g.ID
+	}
+
+	// Convert production companies, languages, networks and creators
+	tv.ProductionCompanies = make([]models.ProductionCompany, len(tmdbTV.ProductionCompanies))
+	for i, pc := range tmdbTV.ProductionCompanies {
+		tv.ProductionCompanies[i] = models.ProductionCompany{ID: pc.ID, Name: pc.Name, LogoPath: pc.LogoPath, OriginCountry: pc.OriginCountry}
+	}
+	tv.SpokenLanguages = make([]models.SpokenLanguage, len(tmdbTV.SpokenLanguages))
+	for i, l := range tmdbTV.SpokenLanguages {
+		tv.SpokenLanguages[i] = models.SpokenLanguage{ISO6391: l.ISO6391, Name: l.Name}
+	}
+	tv.Networks = make([]models.Network, len(tmdbTV.Networks))
+	for i, n := range tmdbTV.Networks {
+		tv.Networks[i] = models.Network{ID: n.ID, Name: n.Name, LogoPath: n.LogoPath, OriginCountry: n.OriginCountry}
+	}
+	tv.CreatedBy = make([]models.Creator, len(tmdbTV.CreatedBy))
+	for i, c := range tmdbTV.CreatedBy {
+		tv.CreatedBy[i] = models.Creator{ID: c.ID, Name: c.Name, ProfilePath: c.ProfilePath}
+	}
+
+	// Convert seasons
+	tv.Seasons = make([]models.SeasonSummary, len(tmdbTV.Seasons))
+	for i, season := range tmdbTV.Seasons {
+		tv.Seasons[i] = models.SeasonSummary{
+			ID:           season.ID,
+			SeasonNumber: season.SeasonNumber,
+			Name:         season.Name,
+			Overview:     season.Overview,
+			AirDate:      season.AirDate,
+			EpisodeCount: season.EpisodeCount,
+			PosterPath:   season.PosterPath,
+		}
+	}
+	if tmdbTV.LastEpisodeToAir != nil {
+		tv.LastEpisodeToAir = convertTMDBEpisode(tmdbTV.ID, *tmdbTV.LastEpisodeToAir)
+	}
+	if tmdbTV.NextEpisodeToAir != nil {
+		tv.NextEpisodeToAir = convertTMDBEpisode(tmdbTV.ID, *tmdbTV.NextEpisodeToAir)
+	}
+
+	// Convert aggregate credits, using the most frequent role or job
+	tv.Credits.Cast = make([]models.CastMember, len(tmdbTV.AggregateCredits.Cast))
+	for i, cast := range tmdbTV.AggregateCredits.Cast {
+		member := models.CastMember{
+			ID:           cast.ID,
+			Name:         cast.Name,
+			ProfilePath:  cast.ProfilePath,
+			Order:        cast.Order,
+			EpisodeCount: cast.TotalEpisodeCount,
+		}
+		mostEpisodes := -1
+		for _, role := range cast.Roles {
+			if role.EpisodeCount > mostEpisodes {
+				member.Character = role.Character
+				mostEpisodes = role.EpisodeCount
+			}
+		}
+		tv.Credits.Cast[i] = member
+	}
+	tv.Credits.Crew = make([]models.CrewMember, len(tmdbTV.AggregateCredits.Crew))
+	for i, crew := range tmdbTV.AggregateCredits.Crew {
+		member := models.CrewMember{
+			ID:           crew.ID,
+			Name:         crew.Name,
+			Department:   crew.Department,
+			ProfilePath:  crew.ProfilePath,
+			EpisodeCount: crew.TotalEpisodeCount,
+		}
+		mostEpisodes := -1
+		for _, job := range crew.Jobs {
+			if job.EpisodeCount > mostEpisodes {
+				member.Job = job.Job
+				mostEpisodes = job.EpisodeCount
+			}
+		}
+		tv.Credits.Crew[i] = member
+	}
+
+	// Pick the first YouTube trailer
+	for _, v := range tmdbTV.Videos.Results {
+		if v.Site == "YouTube" && v.Type == "Trailer" {
+			tv.TrailerKey = v.Key
+			break
+		}
+	}
+
+	// Convert keywords
+	tv.Keywords = make([]models.Keyword, len(tmdbTV.Keywords.Results))
+	for i, k := range tmdbTV.Keywords.Results {
+		tv.Keywords[i] = models.Keyword{ID: k.ID, Name: k.Name}
+	}
+
+	tv.Ratings = models.Ratings{
+		TMDB: tmdbTV.VoteAverage,
+	}
+
+	return tv
+}
+
+// convertTMDBEpisode converts TMDB episode response to our model
+func convertTMDBEpisode(tvID int, tmdbEpisode TMDBEpisodeResponse) *models.Episode {
+	episode := &models.Episode{
+		ID:             tmdbEpisode.ID,
+		ShowID:         tvID,
+		SeasonNumber:   tmdbEpisode.SeasonNumber,
+		EpisodeNumber:  tmdbEpisode.EpisodeNumber,
+		Name:           tmdbEpisode.Name,
+		Overview:       tmdbEpisode.Overview,
+		AirDate:        tmdbEpisode.AirDate,
+		Runtime:        tmdbEpisode.Runtime,
+		StillPath:      tmdbEpisode.StillPath,
+		VoteAverage:    tmdbEpisode.VoteAverage,
+		VoteCount:      tmdbEpisode.VoteCount,
+		ProductionCode: tmdbEpisode.ProductionCode,
+	}
+
+	for _, guest := range tmdbEpisode.GuestStars {
+		episode.GuestStars = append(episode.GuestStars, models.CastMember{
+			ID:          guest.ID,
+			Name:        guest.Name,
+			Character:   guest.Character,
+			ProfilePath: guest.ProfilePath,
+			Order:       guest.Order,
+		})
+	}
+
+	for _, crew := range tmdbEpisode.Crew {
+		episode.Crew = append(episode.Crew, models.CrewMember{
+			ID:          crew.ID,
+			Name:        crew.Name,
+			Job:         crew.Job,
+			Department:  crew.Department,
+			ProfilePath: crew.ProfilePath,
+		})
+	}
+
+	for _, still := range tmdbEpisode.Images.Stills {
+		episode.Stills = append(episode.Stills, convertTMDBImage(still))
+	}
+
+	return episode
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// warmAhead is how close to expiry a cached entry must be before WarmCache
+// refreshes it; it covers the gap between two runs of the default schedule
+const warmAhead = 20 * time.Minute
+
+// refreshKey marks a context whose cache lookups are skipped, so results are
+// fetched from TMDB and replace the cached entries only once they succeed
+type refreshKey struct{}
+
+// withCacheRefresh returns a context that skips cache lookups
+func withCacheRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+// cacheGet looks up a cached value unless ctx asks for a refresh
+func (s *TMDBService) cacheGet(ctx context.Context, key string) (interface{}, bool) {
+	if refresh, _ := ctx.Value(refreshKey{}).(bool); refresh {
+		return nil, false
+	}
+	return s.cache.Get(key)
+}
+
+// WarmCache refreshes the most requested TMDB lists (genres, popular titles,
+// the weekly trending pools and trending people) before they expire so users
+// rarely wait on TMDB. An entry that fails to refresh keeps serving until
+// it expires.
+func (s *TMDBService) WarmCache(ctx context.Context) error {
+	// Fetch fresh results; the cache keeps the old ones until they arrive
+	ctx = withCacheRefresh(ctx)
+
+	warmers := []struct {
+		key   string
+		fetch func() error
+	}{
+		{utils.GenerateCacheKey("tmdb_genres", "movie"), func() error {
+			_, err := s.getGenreList(ctx, "movie")
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_genres", "tv"), func() error {
+			_, err := s.getGenreList(ctx, "tv")
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_list", "movie", ListPopular, "", 1), func() error {
+			_, err := s.GetPopularMedia(ctx, "movie", 1)
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_list", "tv", ListPopular, "", 1), func() error {
+			_, err := s.GetPopularMedia(ctx, "tv", 1)
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_trending_pool", "movie", "week"), func() error {
+			_, err := s.GetTrendingPool(ctx, "movie", "week")
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_trending_pool", "tv", "week"), func() error {
+			_, err := s.GetTrendingPool(ctx, "tv", "week")
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_trending_people", "day"), func() error {
+			_, err := s.GetTrendingPeople(ctx, "day")
+			return err
+		}},
+		{utils.GenerateCacheKey("tmdb_trending_people", "week"), func() error {
+			_, err := s.GetTrendingPeople(ctx, "week")
+			return err
+		}},
+	}
+
+	var errs []error
+	for _, warmer := range warmers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !s.cache.ExpiresWithin(warmer.key, warmAhead) {
+			continue
+		}
+
+		if err := warmer.fetch(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to warm %s: %w", warmer.key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CleanupCache removes expired entries from the cache
+func (s *TMDBService) CleanupCache(ctx context.Context) error {
+	s.cache.Cleanup()
+	return nil
+}
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// Snapshots older than maxTrendingSnapshotAge are dropped, and no chart keeps
+// more than maxTrendingSnapshots of them
+const (
+	maxTrendingSnapshotAge = 14 * 24 * time.Hour
+	maxTrendingSnapshots   = 1000
+)
+
+// ErrNoTrendingSnapshots is returned when there aren't enough snapshots to
+// answer a question about trending history
+var ErrNoTrendingSnapshots = errors.New("not enough trending snapshots yet")
+
+// trendingSnapshotState names the snapshots in the shared store
+const trendingSnapshotState = "trending_snapshots"
+
+// TrendingSnapshotService records the trending charts over time so clients can
+// see how titles rise and fall. Snapshots live in the shared store, so one
+// replica captures them and the others pick them up on Refresh.
+type TrendingSnapshotService struct {
+	tmdbService *TMDBService
+	store       StateStore
+
+	mu        sync.RWMutex
+	snapshots map[string][]models.TrendingSnapshot // "type:timeframe" -> snapshots, newest first
+}
+
+// NewTrendingSnapshotService creates a new trending snapshot service instance
+func NewTrendingSnapshotService(tmdbService *TMDBService, store StateStore) *TrendingSnapshotService {
+	return &TrendingSnapshotService{
+		tmdbService: tmdbService,
+		store:       store,
+		snapshots:   make(map[string][]models.TrendingSnapshot),
+	}
+}
+
+// snapshotKey identifies a trending chart
+func snapshotKey(mediaType, timeframe string) string {
+	return mediaType + ":" + timeframe
+}
+
+// Load restores the snapshots persisted by a previous run
+func (s *TrendingSnapshotService) Load() error {
+	return s.Refresh(context.Background())
+}
+
+// Refresh replaces the snapshots with those in the shared store, picking up
+// captures made by other replicas
+func (s *TrendingSnapshotService) Refresh(ctx context.Context) error {
+	snapshots := make(map[string][]models.TrendingSnapshot)
+	if err := s.store.Load(ctx, trendingSnapshotState, &snapshots); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots = snapshots
+	return nil
+}
+
+// Capture records the current daily and weekly trending charts for movies and
+// TV shows and persists them
+func (s *TrendingSnapshotService) Capture(ctx context.Context) error {
+	now := time.Now()
+	captured := []models.TrendingSnapshot{}
+
+	var errs []error
+	for _, mediaType := range []string{models.MediaTypeMovie, models.MediaTypeTV} {
+		for _, timeframe := range []string{"day", "week"} {
+			result, err := s.tmdbService.GetTrendingMedia(ctx, timeframe, 1, mediaType)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get trending %s %s: %w", mediaType, timeframe, err))
+				continue
+			}
+			if len(result.Results) == 0 {
+				errs = append(errs, fmt.Errorf("no trending results for %s %s", mediaType, timeframe))
+				continue
+			}
+
+			snapshot := models.TrendingSnapshot{
+				MediaType:  mediaType,
+				Timeframe:  timeframe,
+				CapturedAt: now,
+				Entries:    make([]models.TrendingSnapshotEntry, len(result.Results)),
+			}
+			for i, media := range result.Results {
+				snapshot.Entries[i] = models.TrendingSnapshotEntry{
+					Rank:       i + 1,
+					ID:         media.ID,
+					MediaType:  media.MediaType,
+					Title:      media.Title,
+					PosterPath: media.PosterPath,
+					Popularity: media.Popularity,
+					GenreIDs:   media.GenreIDs,
+				}
+			}
+			captured = append(captured, snapshot)
+		}
+	}
+
+	if len(captured) > 0 {
+		// Start from the latest stored snapshots so another replica's
+		// captures aren't overwritten
+		if err := s.Refresh(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		s.mu.Lock()
+		for _, snapshot := range captured {
+			key := snapshotKey(snapshot.MediaType, snapshot.Timeframe)
+			snapshots := append([]models.TrendingSnapshot{snapshot}, s.snapshots[key]...)
+
+			// Drop snapshots past the retention window
+			keep := len(snapshots)
+			for keep > 1 && now.Sub(snapshots[keep-1].CapturedAt) > maxTrendingSnapshotAge {
+				keep--
+			}
+			if keep > maxTrendingSnapshots {
+				keep = maxTrendingSnapshots
+			}
+			s.snapshots[key] = snapshots[:keep]
+		}
+		persisted := make(map[string][]models.TrendingSnapshot, len(s.snapshots))
+		for key, snapshots := range s.snapshots {
+			persisted[key] = snapshots
+		}
+		s.mu.Unlock()
+
+		if err := s.store.Save(ctx, trendingSnapshotState, persisted); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetSnapshots returns up to limit snapshots of a chart, newest first
+func (s *TrendingSnapshotService) GetSnapshots(ctx context.Context, mediaType string, timeframe string, limit int) []models.TrendingSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[snapshotKey(mediaType, timeframe)]
+	if limit > 0 && len(snapshots) > limit {
+		snapshots = snapshots[:limit]
+	}
+
+	return append([]models.TrendingSnapshot{}, snapshots...)
+}
+
+// GetMovers compares the latest chart with the one captured since ago. When
+// history doesn't reach back that far the oldest snapshot is used instead.
+func (s *TrendingSnapshotService) GetMovers(ctx context.Context, mediaType string, timeframe string, since time.Duration) (*models.TrendingMovers, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[snapshotKey(mediaType, timeframe)]
+	if len(snapshots) < 2 {
+		return nil, ErrNoTrendingSnapshots
+	}
+
+	latest := snapshots[0]
+	baseline := snapshots[len(snapshots)-1]
+	target := latest.CapturedAt.Add(-since)
+	for _, snapshot := range snapshots[1:] {
+		if !snapshot.CapturedAt.After(target) {
+			baseline = snapshot
+			break
+		}
+	}
+
+	movers := &models.TrendingMovers{
+		MediaType:  mediaType,
+		Timeframe:  timeframe,
+		CapturedAt: latest.CapturedAt,
+		ComparedTo: baseline.CapturedAt,
+		Rising:     []models.TrendingMover{},
+		Falling:    []models.TrendingMover{},
+		New:        []models.TrendingMover{},
+		DroppedOut: []models.TrendingMover{},
+	}
+
+	previous := make(map[int]models.TrendingSnapshotEntry, len(baseline.Entries))
+	for _, entry := range baseline.Entries {
+		previous[entry.ID] = entry
+	}
+	current := make(map[int]bool, len(latest.Entries))
+
+	for _, entry := range latest.Entries {
+		current[entry.ID] = true
+		mover := newTrendingMover(entry)
+		mover.Rank = entry.Rank
+
+		before, existed := previous[entry.ID]
+		if !existed {
+			movers.New = append(movers.New, mover)
+			continue
+		}
+		mover.PreviousRank = before.Rank
+		mover.Change = before.Rank - entry.Rank
+		if mover.Change > 0 {
+			movers.Rising = append(movers.Rising, mover)
+		} else if mover.Change < 0 {
+			movers.Falling = append(movers.Falling, mover)
+		}
+	}
+	for _, entry := range baseline.Entries {
+		if !current[entry.ID] {
+			mover := newTrendingMover(entry)
+			mover.PreviousRank = entry.Rank
+			movers.DroppedOut = append(movers.DroppedOut, mover)
+		}
+	}
+
+	// Biggest moves first
+	sort.SliceStable(movers.Rising, func(i, j int) bool {
+		return movers.Rising[i].Change > movers.Rising[j].Change
+	})
+	sort.SliceStable(movers.Falling, func(i, j int) bool {
+		return movers.Falling[i].Change < movers.Falling[j].Change
+	})
+
+	return movers, nil
+}
+
+// newTrendingMover creates a mover for a snapshot entry without any ranks
+func newTrendingMover(entry models.TrendingSnapshotEntry) models.TrendingMover {
+	return models.TrendingMover{
+		ID:         entry.ID,
+		MediaType:  entry.MediaType,
+		Title:      entry.Title,
+		PosterPath: entry.PosterPath,
+	}
+}
+
+// GetTitleHistory returns a title's rank in every recorded snapshot of a chart
+// and how long it has been in the chart without dropping out
+func (s *TrendingSnapshotService) GetTitleHistory(ctx context.Context, mediaType string, timeframe string, id int) (*models.TrendingHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[snapshotKey(mediaType, timeframe)]
+	if len(snapshots) == 0 {
+		return nil, ErrNoTrendingSnapshots
+	}
+
+	return titleHistory(snapshots, mediaType, timeframe, id, true), nil
+}
+
+// GetLongestTrending returns the titles in the latest chart that have been
+// trending the longest without dropping out
+func (s *TrendingSnapshotService) GetLongestTrending(ctx context.Context, mediaType string, timeframe string, limit int) ([]models.TrendingHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[snapshotKey(mediaType, timeframe)]
+	if len(snapshots) == 0 {
+		return nil, ErrNoTrendingSnapshots
+	}
+
+	histories := make([]models.TrendingHistory, 0, len(snapshots[0].Entries))
+	for _, entry := range snapshots[0].Entries {
+		histories = append(histories, *titleHistory(snapshots, mediaType, timeframe, entry.ID, false))
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		if histories[i].StreakHours != histories[j].StreakHours {
+			return histories[i].StreakHours > histories[j].StreakHours
+		}
+		return histories[i].CurrentRank < histories[j].CurrentRank
+	})
+	if limit > 0 && len(histories) > limit {
+		histories = histories[:limit]
+	}
+
+	return histories, nil
+}
+
+// titleHistory builds a title's history from a chart's snapshots (newest
+// first), optionally including every point
+func titleHistory(snapshots []models.TrendingSnapshot, mediaType string, timeframe string, id int, withPoints bool) *models.TrendingHistory {
+	history := &models.TrendingHistory{
+		ID:        id,
+		MediaType: mediaType,
+		Timeframe: timeframe,
+		Points:    []models.TrendingHistoryPoint{},
+	}
+
+	// Walk oldest to newest so points come out in chronological order
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		for _, entry := range snapshot.Entries {
+			if entry.ID != id {
+				continue
+			}
+			if history.Appearances == 0 {
+				firstSeen := snapshot.CapturedAt
+				history.FirstSeen = &firstSeen
+			}
+			history.Appearances++
+			history.Title = entry.Title
+			history.PosterPath = entry.PosterPath
+			if history.BestRank == 0 || entry.Rank < history.BestRank {
+				history.BestRank = entry.Rank
+			}
+			if i == 0 {
+				history.CurrentRank = entry.Rank
+			}
+			if withPoints {
+				history.Points = append(history.Points, models.TrendingHistoryPoint{
+					CapturedAt: snapshot.CapturedAt,
+					Rank:       entry.Rank,
+				})
+			}
+			break
+		}
+	}
+
+	// The streak runs back from the latest snapshot until the title was missing
+	if history.CurrentRank > 0 {
+		streakStart := snapshots[0].CapturedAt
+		for _, snapshot := range snapshots {
+			if !snapshotContains(snapshot, id) {
+				break
+			}
+			streakStart = snapshot.CapturedAt
+		}
+		history.StreakStartedAt = &streakStart
+		history.StreakHours = snapshots[0].CapturedAt.Sub(streakStart).Hours()
+	}
+
+	return history
+}
+
+// snapshotContains reports whether a title is in a snapshot
+func snapshotContains(snapshot models.TrendingSnapshot, id int) bool {
+	for _, entry := range snapshot.Entries {
+		if entry.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGenreShareHistory returns, for every genre, its share of the recorded
+// top 20 over the last points captures, oldest first. Charts for several media
+// types are combined when they were captured together.
+func (s *TrendingSnapshotService) GetGenreShareHistory(ctx context.Context, mediaTypes []string, timeframe string, points int) map[int][]models.TrendingGenrePoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Group entries by capture time across media types
+	type capture struct {
+		capturedAt time.Time
+		total      int
+		counts     map[int]int
+	}
+	captures := make(map[int64]*capture)
+	for _, mediaType := range mediaTypes {
+		snapshots := s.snapshots[snapshotKey(mediaType, timeframe)]
+		if points > 0 && len(snapshots) > points {
+			snapshots = snapshots[:points]
+		}
+		for _, snapshot := range snapshots {
+			key := snapshot.CapturedAt.Unix()
+			c, exists := captures[key]
+			if !exists {
+				c = &capture{capturedAt: snapshot.CapturedAt, counts: make(map[int]int)}
+				captures[key] = c
+			}
+			for _, entry := range snapshot.Entries {
+				c.total++
+				for _, genreID := range entry.GenreIDs {
+					c.counts[genreID]++
+				}
+			}
+		}
+	}
+
+	ordered := make([]*capture, 0, len(captures))
+	for _, c := range captures {
+		ordered = append(ordered, c)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].capturedAt.Before(ordered[j].capturedAt)
+	})
+	if points > 0 && len(ordered) > points {
+		ordered = ordered[len(ordered)-points:]
+	}
+
+	// Every genre seen gets a point per capture, including zero shares
+	history := make(map[int][]models.TrendingGenrePoint)
+	for _, c := range ordered {
+		for genreID := range c.counts {
+			history[genreID] = nil
+		}
+	}
+	for genreID := range history {
+		genrePoints := make([]models.TrendingGenrePoint, len(ordered))
+		for i, c := range ordered {
+			genrePoints[i] = models.TrendingGenrePoint{
+				CapturedAt: c.capturedAt,
+				Share:      float64(c.counts[genreID]) / float64(c.total),
+			}
+		}
+		history[genreID] = genrePoints
+	}
+
+	return history
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// Collaborative filtering tuning. Items saved by fewer than minItemUsers
+// users and users with fewer than minUserItems items are cold: they get no
+// collaborative score and fall back to content-based recommendations.
+const (
+	minItemUsers         = 3
+	minUserItems         = 3
+	minCoUsers           = 2  // users two items need in common to be neighbors
+	similarityShrinkage  = 5  // damps similarities backed by few shared users
+	maxItemNeighbors     = 50 // most similar items kept per item
+	maxCollaborativePool = 50 // best collaborative predictions added as candidates
+	minNeighborSupport   = 3  // matched neighbors needed for full confidence
+	collaborativeBlend   = 0.4
+)
+
+// itemNeighbor is an item similar to another, by who saved and rated both
+type itemNeighbor struct {
+	ref        mediaRef
+	similarity float64
+}
+
+// itemSimilarityModel is an item-item collaborative filtering model built
+// from every user's watchlist
+type itemSimilarityModel struct {
+	neighbors map[mediaRef][]itemNeighbor
+	cards     map[mediaRef]models.Media // media card per item, for candidates
+	builtAt   time.Time
+}
+
+// collaborativePrediction is how much a user is predicted to like an item
+// based on their opinion of its neighbors
+type collaborativePrediction struct {
+	score      float64 // between -1 and 1
+	confidence float64 // between 0 and 1
+}
+
+// RebuildSimilarityModel rebuilds the item-item collaborative filtering model
+// from all watchlists
+func (s *WatchlistService) RebuildSimilarityModel(ctx context.Context) error {
+	model, err := buildSimilarityModel(ctx, s.GetAllWatchlistItems())
+	if err != nil {
+		return err
+	}
+
+	s.modelMu.Lock()
+	s.similarityModel = model
+	s.modelMu.Unlock()
+
+	return nil
+}
+
+// buildSimilarityModel builds an item-item collaborative filtering model from
+// a snapshot of watchlists. Each user's opinion of an item is the same rating
+// and status weight the taste profile uses, and items are compared by the
+// cosine similarity of those opinions across users.
+func buildSimilarityModel(ctx context.Context, watchlists map[string][]models.WatchlistItem) (*itemSimilarityModel, error) {
+	// Collect each user's opinions
+	opinions := make(map[string]map[mediaRef]float64)
+	users := make(map[mediaRef]int)
+	cards := make(map[mediaRef]models.Media)
+	for userID, items := range watchlists {
+		userOpinions := make(map[mediaRef]float64, len(items))
+		for _, item := range items {
+			ref := itemRef(item)
+			if _, seen := userOpinions[ref]; seen {
+				continue
+			}
+			userOpinions[ref] = itemWeight(item)
+			users[ref]++
+			if _, exists := cards[ref]; !exists {
+				card := item.Media
+				card.MediaType = ref.mediaType
+				cards[ref] = card
+			}
+		}
+		opinions[userID] = userOpinions
+	}
+
+	// Accumulate dot products and norms over items with enough users
+	type itemPair struct{ a, b mediaRef }
+	dots := make(map[itemPair]float64)
+	shared := make(map[itemPair]int)
+	norms := make(map[mediaRef]float64)
+	for _, userOpinions := range opinions {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var refs []mediaRef
+		for ref, weight := range userOpinions {
+			if users[ref] >= minItemUsers {
+				refs = append(refs, ref)
+				norms[ref] += weight * weight
+			}
+		}
+		for i := 0; i < len(refs); i++ {
+			for j := i + 1; j < len(refs); j++ {
+				a, b := refs[i], refs[j]
+				if a.mediaType > b.mediaType || (a.mediaType == b.mediaType && a.id > b.id) {
+					a, b = b, a
+				}
+				pair := itemPair{a, b}
+				dots[pair] += userOpinions[a] * userOpinions[b]
+				shared[pair]++
+			}
+		}
+	}
+
+	// Turn them into shrunk cosine similarities
+	neighbors := make(map[mediaRef][]itemNeighbor)
+	for pair, dot := range dots {
+		if shared[pair] < minCoUsers || norms[pair.a] == 0 || norms[pair.b] == 0 {
+			continue
+		}
+		similarity := dot / (math.Sqrt(norms[pair.a]) * math.Sqrt(norms[pair.b]))
+		similarity *= float64(shared[pair]) / float64(shared[pair]+similarityShrinkage)
+		if similarity == 0 {
+			continue
+		}
+		neighbors[pair.a] = append(neighbors[pair.a], itemNeighbor{ref: pair.b, similarity: similarity})
+		neighbors[pair.b] = append(neighbors[pair.b], itemNeighbor{ref: pair.a, similarity: similarity})
+	}
+	for ref, list := range neighbors {
+		sort.Slice(list, func(i, j int) bool {
+			return math.Abs(list[i].similarity) > math.Abs(list[j].similarity)
+		})
+		if len(list) > maxItemNeighbors {
+			list = list[:maxItemNeighbors]
+		}
+		neighbors[ref] = list
+	}
+
+	return &itemSimilarityModel{
+		neighbors: neighbors,
+		cards:     cards,
+		builtAt:   time.Now(),
+	}, nil
+}
+
+// collaborativePredictions predicts how much a user likes the items similar
+// to the ones on their watchlist
+func (s *WatchlistService) collaborativePredictions(weighted []weightedItem) map[mediaRef]collaborativePrediction {
+	s.modelMu.RLock()
+	model := s.similarityModel
+	s.modelMu.RUnlock()
+
+	if model == nil {
+		return nil
+	}
+	return model.predict(weighted)
+}
+
+// collaborativeCandidates returns the best predicted items as candidates so
+// titles only other users surfaced can be recommended too
+func (s *WatchlistService) collaborativeCandidates(predictions map[mediaRef]collaborativePrediction) []models.Media {
+	s.modelMu.RLock()
+	model := s.similarityModel
+	s.modelMu.RUnlock()
+
+	if model == nil {
+		return nil
+	}
+	return model.candidates(predictions, maxCollaborativePool)
+}
+
+// predict predicts how much a user likes the items similar to the ones they
+// weighted. Users with too few items get no predictions.
+func (model *itemSimilarityModel) predict(weighted []weightedItem) map[mediaRef]collaborativePrediction {
+	if len(weighted) < minUserItems {
+		return nil
+	}
+
+	// Weighted average of the user's opinions of each item's neighbors
+	sums := make(map[mediaRef]float64)
+	totals := make(map[mediaRef]float64)
+	support := make(map[mediaRef]int)
+	for _, w := range weighted {
+		for _, neighbor := range model.neighbors[itemRef(w.item)] {
+			sums[neighbor.ref] += neighbor.similarity * w.weight
+			totals[neighbor.ref] += math.Abs(neighbor.similarity)
+			support[neighbor.ref]++
+		}
+	}
+
+	predictions := make(map[mediaRef]collaborativePrediction, len(sums))
+	for ref, sum := range sums {
+		if totals[ref] == 0 {
+			continue
+		}
+		predictions[ref] = collaborativePrediction{
+			score:      sum / totals[ref],
+			confidence: math.Min(1, float64(support[ref])/minNeighborSupport),
+		}
+	}
+
+	return predictions
+}
+
+// candidates returns the media cards of up to limit items with the best
+// positive predictions
+func (model *itemSimilarityModel) candidates(predictions map[mediaRef]collaborativePrediction, limit int) []models.Media {
+	refs := make([]mediaRef, 0, len(predictions))
+	for ref, prediction := range predictions {
+		if prediction.score > 0 {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		pi, pj := predictions[refs[i]], predictions[refs[j]]
+		if pi.score*pi.confidence != pj.score*pj.confidence {
+			return pi.score*pi.confidence > pj.score*pj.confidence
+		}
+		return refs[i].id < refs[j].id
+	})
+	if len(refs) > limit {
+		refs = refs[:limit]
+	}
+
+	cards := make([]models.Media, 0, len(refs))
+	for _, ref := range refs {
+		if card, exists := model.cards[ref]; exists {
+			cards = append(cards, card)
+		}
+	}
+
+	return cards
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"math"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// recommendationDiversity trades relevance for variety when picking results:
+// 0 ranks purely by score, 1 purely by how different a title is from the
+// ones already picked
+const recommendationDiversity = 0.25
+
+// diversityTraits are the traits results are spread across
+type diversityTraits struct {
+	genres   []int
+	decade   int
+	language string
+}
+
+// diversify picks up to limit recommendations by maximal marginal relevance.
+// Each pick is the result whose score, less a penalty for its overlap with the
+// results already picked, is highest, so a run of titles in the same genre,
+// decade and language gets broken up. Recommendations must be sorted by score.
+func diversify(recommendations []models.WatchlistRecommendation, limit int) []models.WatchlistRecommendation {
+	traits := make([]diversityTraits, len(recommendations))
+	for i, recommendation := range recommendations {
+		traits[i] = mediaDiversityTraits(recommendation.Media)
+	}
+
+	picked := make([]models.WatchlistRecommendation, 0, limit)
+	pickedTraits := make([]diversityTraits, 0, limit)
+	used := make([]bool, len(recommendations))
+	for len(picked) < limit && len(picked) < len(recommendations) {
+		best, bestValue, bestRedundancy := -1, 0.0, 0.0
+		for i, recommendation := range recommendations {
+			if used[i] {
+				continue
+			}
+
+			redundancy := 0.0
+			for _, other := range pickedTraits {
+				redundancy = math.Max(redundancy, traitSimilarity(traits[i], other))
+			}
+			value := (1-recommendationDiversity)*recommendation.Score - recommendationDiversity*redundancy
+			if best == -1 || value > bestValue {
+				best, bestValue, bestRedundancy = i, value, redundancy
+			}
+		}
+
+		used[best] = true
+		recommendation := recommendations[best]
+		recommendation.Redundancy = bestRedundancy
+		picked = append(picked, recommendation)
+		pickedTraits = append(pickedTraits, traits[best])
+	}
+
+	return picked
+}
+
+// mediaDiversityTraits returns the traits of a title results are spread across
+func mediaDiversityTraits(media models.Media) diversityTraits {
+	traits := diversityTraits{
+		genres:   mediaGenreIDs(media),
+		language: media.OriginalLanguage,
+	}
+	if year := utils.ParseYear(media.ReleaseDate); year > 0 {
+		traits.decade = year / 10 * 10
+	}
+	return traits
+}
+
+// traitSimilarity returns how much two titles overlap, between 0 and 1.
+// Genres weigh most, by the share of genres they have in common.
+func traitSimilarity(a, b diversityTraits) float64 {
+	similarity := 0.0
+
+	if len(a.genres) > 0 && len(b.genres) > 0 {
+		shared := 0
+		for _, genreID := range a.genres {
+			for _, other := range b.genres {
+				if genreID == other {
+					shared++
+					break
+				}
+			}
+		}
+		similarity += 0.6 * float64(shared) / float64(len(a.genres)+len(b.genres)-shared)
+	}
+	if a.decade > 0 && a.decade == b.decade {
+		similarity += 0.2
+	}
+	if a.language != "" && a.language == b.language {
+		similarity += 0.2
+	}
+
+	return similarity
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// describeLikedTitle words an explanation for a liked watchlist title TMDB
+// relates the recommendation to
+func describeLikedTitle(explanation *models.RecommendationExplanation, seed weightedItem) {
+	title := seed.item.Media.Title
+	explanation.MediaType = itemMediaType(seed.item)
+	explanation.MediaID = seed.item.MovieID
+
+	switch {
+	case seed.feedback == models.FeedbackMoreLikeThis:
+		explanation.Text = "Because you asked for more like " + title
+	case seed.item.Rating > 0:
+		explanation.Text = fmt.Sprintf("Because you rated %s %g/10", title, seed.item.Rating)
+	case seed.item.Status == "watching":
+		explanation.Text = "Because you're watching " + title
+	case seed.item.Status == "completed":
+		explanation.Text = "Because you finished " + title
+	default:
+		explanation.Text = "Because " + title + " is on your watchlist"
+	}
+}
+
+// describeExplanation words an explanation, naming the genres, themes and
+// people of the recommended title and the liked titles that share them
+func describeExplanation(explanation *models.RecommendationExplanation, media models.Media, profile tasteProfile) {
+	switch explanation.Type {
+	case models.ExplanationRating:
+		explanation.Text = fmt.Sprintf("Rated %.1f on TMDB, close to the titles you like", media.VoteAverage)
+		return
+	case models.ExplanationMediaType:
+		noun := "A movie"
+		if media.MediaType == models.MediaTypeTV {
+			noun = "A TV show"
+		}
+		explanation.Text = fmt.Sprintf("%s, like %.0f%% of your watchlist", noun, explanation.Affinity*100)
+		return
+	case models.ExplanationPopularity:
+		explanation.Text = "Popular on TMDB right now"
+		return
+	case models.ExplanationCollaborative:
+		explanation.Text = "Liked by people with similar taste"
+		if explanation.Affinity < 0 {
+			explanation.Text = "Not a hit with people with similar taste"
+		}
+		return
+	}
+
+	label := featureLabel(explanation.Feature, media)
+	personID := featurePersonID(explanation.Feature)
+	if personID > 0 {
+		explanation.PersonID = personID
+	}
+
+	example := profile.examples[explanation.Feature]
+	switch {
+	case explanation.Affinity > 0 && profile.followed[personID]:
+		explanation.Text = label + ", whom you follow"
+	case explanation.Affinity > 0 && example != "":
+		explanation.Text = label + ", like " + example
+	case explanation.Affinity < 0:
+		explanation.Text = label + ", unlike what you usually enjoy"
+	default:
+		explanation.Text = label
+	}
+}
+
+// featureLabel names a taste profile trait as it applies to a title
+func featureLabel(feature string, media models.Media) string {
+	kind, value, _ := strings.Cut(feature, ":")
+	id, _ := strconv.Atoi(value)
+
+	switch kind {
+	case "genre":
+		for _, genre := range media.Genres {
+			if genre.ID == id {
+				return genre.Name
+			}
+		}
+		return "Its genre"
+	case "keyword":
+		for _, keyword := range media.Keywords {
+			if keyword.ID == id {
+				return "About " + keyword.Name
+			}
+		}
+		return "Its themes"
+	case "cast":
+		for _, cast := range media.Credits.Cast {
+			if cast.ID == id {
+				return "Stars " + cast.Name
+			}
+		}
+		return "Its cast"
+	case "director":
+		for _, crew := range media.Credits.Crew {
+			if crew.ID == id && crew.Job == "Director" {
+				return "Directed by " + crew.Name
+			}
+		}
+		if media.TVDetails != nil {
+			for _, creator := range media.CreatedBy {
+				if creator.ID == id {
+					return "Created by " + creator.Name
+				}
+			}
+		}
+		return "Its director"
+	case "language":
+		for _, language := range media.SpokenLanguages {
+			if language.ISO6391 == value && language.Name != "" {
+				return "Originally in " + language.Name
+			}
+		}
+		return "Originally in " + strings.ToUpper(value)
+	case "decade":
+		return "From the " + value + "s"
+	case "runtime":
+		mediaType, length, _ := strings.Cut(value, ":")
+		if mediaType == models.MediaTypeTV {
+			return map[string]string{"short": "Short episodes", "medium": "Standard-length episodes", "long": "Long episodes"}[length]
+		}
+		return map[string]string{"short": "A short movie", "medium": "A standard-length movie", "long": "A long movie"}[length]
+	}
+
+	return feature
+}
+
+// featurePersonID returns the TMDB person ID of a cast or director trait, or
+// 0 for other traits
+func featurePersonID(feature string) int {
+	kind, value, _ := strings.Cut(feature, ":")
+	if kind != "cast" && kind != "director" {
+		return 0
+	}
+	id, _ := strconv.Atoi(value)
+	return id
+}
+
+// strongestExplanations drops explanations that didn't move the score and
+// keeps the strongest ones, those for the recommendation first
+func strongestExplanations(explanations []models.RecommendationExplanation) []models.RecommendationExplanation {
+	kept := []models.RecommendationExplanation{}
+	for _, explanation := range explanations {
+		if math.Abs(explanation.Contribution) >= 0.001 {
+			kept = append(kept, explanation)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return math.Abs(kept[i].Contribution) > math.Abs(kept[j].Contribution)
+	})
+	if len(kept) > maxExplanations {
+		kept = kept[:maxExplanations]
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Contribution > kept[j].Contribution
+	})
+
+	return kept
+}
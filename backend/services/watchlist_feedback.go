@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// How much feedback pulls the taste profile: "more like this" counts like a
+// top rating and "not interested" like a dropped title. "Already seen" only
+// hides the title.
+const (
+	moreLikeThisWeight  = 1.0
+	notInterestedWeight = -0.7
+)
+
+// LoadRecommendationFeedback restores the feedback persisted by a previous run
+func (s *WatchlistService) LoadRecommendationFeedback() error {
+	feedback := make(map[string][]models.RecommendationFeedback)
+	if err := utils.LoadJSONFile(s.feedbackPath, &feedback); err != nil {
+		return err
+	}
+
+	s.feedbackMu.Lock()
+	defer s.feedbackMu.Unlock()
+
+	if feedback != nil {
+		s.feedback = feedback
+	}
+	return nil
+}
+
+// AddRecommendationFeedback records feedback on a recommended title,
+// replacing earlier feedback on the same title. It takes effect on the next
+// GetRecommendations call.
+func (s *WatchlistService) AddRecommendationFeedback(ctx context.Context, userID string, request models.RecommendationFeedbackRequest) (*models.RecommendationFeedback, error) {
+	mediaType := request.MediaType
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		return nil, fmt.Errorf("invalid media type: %s", mediaType)
+	}
+	switch request.Feedback {
+	case models.FeedbackNotInterested, models.FeedbackAlreadySeen, models.FeedbackMoreLikeThis:
+	default:
+		return nil, fmt.Errorf("invalid feedback: %s", request.Feedback)
+	}
+
+	// Get the title for display; feedback still counts without it
+	feedback := models.RecommendationFeedback{
+		MediaType: mediaType,
+		MediaID:   request.MediaID,
+		Feedback:  request.Feedback,
+		CreatedAt: time.Now(),
+	}
+	if media, err := s.getMediaDetails(ctx, mediaType, request.MediaID); err == nil {
+		feedback.Title = media.Title
+	}
+
+	s.feedbackMu.Lock()
+	entries := []models.RecommendationFeedback{feedback}
+	for _, entry := range s.feedback[userID] {
+		if entry.MediaType != mediaType || entry.MediaID != request.MediaID {
+			entries = append(entries, entry)
+		}
+	}
+	s.feedback[userID] = entries
+	s.feedbackRevs[userID]++
+	s.feedbackMu.Unlock()
+
+	if err := s.saveRecommendationFeedback(); err != nil {
+		return nil, err
+	}
+
+	return &feedback, nil
+}
+
+// RemoveRecommendationFeedback forgets a user's feedback on a title
+func (s *WatchlistService) RemoveRecommendationFeedback(ctx context.Context, userID string, mediaType string, mediaID int) error {
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+
+	s.feedbackMu.Lock()
+	entries := []models.RecommendationFeedback{}
+	for _, entry := range s.feedback[userID] {
+		if entry.MediaType != mediaType || entry.MediaID != mediaID {
+			entries = append(entries, entry)
+		}
+	}
+	removed := len(entries) < len(s.feedback[userID])
+	if removed {
+		s.feedback[userID] = entries
+		s.feedbackRevs[userID]++
+	}
+	s.feedbackMu.Unlock()
+
+	if !removed {
+		return fmt.Errorf("feedback not found")
+	}
+
+	return s.saveRecommendationFeedback()
+}
+
+// GetRecommendationFeedback returns a user's feedback, newest first
+func (s *WatchlistService) GetRecommendationFeedback(ctx context.Context, userID string) []models.RecommendationFeedback {
+	feedback, _ := s.recommendationFeedback(userID)
+	return feedback
+}
+
+// recommendationFeedback returns a copy of a user's feedback along with how
+// many times it has changed, which keys the recommendations cache
+func (s *WatchlistService) recommendationFeedback(userID string) ([]models.RecommendationFeedback, int) {
+	s.feedbackMu.RLock()
+	defer s.feedbackMu.RUnlock()
+
+	return append([]models.RecommendationFeedback{}, s.feedback[userID]...), s.feedbackRevs[userID]
+}
+
+// saveRecommendationFeedback persists every user's feedback
+func (s *WatchlistService) saveRecommendationFeedback() error {
+	s.feedbackMu.RLock()
+	persisted := make(map[string][]models.RecommendationFeedback, len(s.feedback))
+	for userID, entries := range s.feedback {
+		persisted[userID] = entries
+	}
+	s.feedbackMu.RUnlock()
+
+	return utils.SaveJSONFile(s.feedbackPath, persisted)
+}
+
+// feedbackItems turns feedback into weighted items for the taste profile
+func feedbackItems(feedback []models.RecommendationFeedback) []weightedItem {
+	var items []weightedItem
+	for _, entry := range feedback {
+		weight := 0.0
+		switch entry.Feedback {
+		case models.FeedbackMoreLikeThis:
+			weight = moreLikeThisWeight
+		case models.FeedbackNotInterested:
+			weight = notInterestedWeight
+		default:
+			continue
+		}
+		items = append(items, weightedItem{
+			item: models.WatchlistItem{
+				MovieID:   entry.MediaID,
+				MediaType: entry.MediaType,
+				Media:     models.Media{ID: entry.MediaID, Title: entry.Title, MediaType: entry.MediaType},
+			},
+			weight:   weight,
+			feedback: entry.Feedback,
+		})
+	}
+	return items
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// mediaRef identifies a title in TMDB; movie and TV IDs overlap
+type mediaRef struct {
+	mediaType string
+	id        int
+}
+
+// itemRef returns the TMDB reference of a watchlist item
+func itemRef(item models.WatchlistItem) mediaRef {
+	return mediaRef{mediaType: itemMediaType(item), id: item.MovieID}
+}
+
+// RefreshSnapshots refetches every watchlist snapshot older than the snapshot
+// TTL, across all users. Titles shared by several watchlists are fetched once.
+func (s *WatchlistService) RefreshSnapshots(ctx context.Context) error {
+	cutoff := time.Now().Add(-config.AppConfig.Watchlist.SnapshotTTL)
+
+	s.mu.RLock()
+	seen := make(map[mediaRef]bool)
+	var refs []mediaRef
+	for _, watchlist := range s.watchlists {
+		for _, item := range watchlist.Items {
+			ref := itemRef(item)
+			if seen[ref] || (item.Snapshot != nil && item.Snapshot.RefreshedAt.After(cutoff)) {
+				continue
+			}
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	s.mu.RUnlock()
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	snapshots := s.fetchSnapshots(ctx, refs)
+
+	s.mu.Lock()
+	for _, watchlist := range s.watchlists {
+		applySnapshots(watchlist, snapshots)
+	}
+	s.mu.Unlock()
+
+	if failed := len(refs) - len(snapshots); failed > 0 {
+		return fmt.Errorf("failed to refresh %d of %d watchlist snapshots", failed, len(refs))
+	}
+
+	return nil
+}
+
+// hydrateMissing fetches snapshots for items in a user's watchlist that have
+// none yet, e.g. because TMDB was unavailable when they were added
+func (s *WatchlistService) hydrateMissing(ctx context.Context, userID string) {
+	s.mu.RLock()
+	var refs []mediaRef
+	if watchlist, exists := s.watchlists[userID]; exists {
+		seen := make(map[mediaRef]bool)
+		for _, item := range watchlist.Items {
+			ref := itemRef(item)
+			if item.Snapshot == nil && !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	if len(refs) == 0 {
+		return
+	}
+
+	snapshots := s.fetchSnapshots(ctx, refs)
+
+	s.mu.Lock()
+	if watchlist, exists := s.watchlists[userID]; exists {
+		applySnapshots(watchlist, snapshots)
+	}
+	s.mu.Unlock()
+}
+
+// fetchSnapshots fetches titles through a bounded pool of workers. Titles that
+// fail to load are left out of the result.
+func (s *WatchlistService) fetchSnapshots(ctx context.Context, refs []mediaRef) map[mediaRef]*models.MediaSnapshot {
+	workers := config.AppConfig.Watchlist.HydrateWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(refs) {
+		workers = len(refs)
+	}
+
+	jobs := make(chan mediaRef)
+	snapshots := make(map[mediaRef]*models.MediaSnapshot, len(refs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range jobs {
+				media, err := s.getMediaDetails(ctx, ref.mediaType, ref.id)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				snapshots[ref] = models.NewMediaSnapshot(*media)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, ref := range refs {
+		select {
+		case jobs <- ref:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return snapshots
+}
+
+// runPool calls fn for every index through a bounded pool of workers
+func runPool(ctx context.Context, count int, fn func(i int)) {
+	workers := config.AppConfig.Watchlist.HydrateWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > count {
+		workers = count
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// applySnapshots stores fetched snapshots on the matching items; callers must
+// hold the write lock
+func applySnapshots(watchlist *models.Watchlist, snapshots map[mediaRef]*models.MediaSnapshot) {
+	for i := range watchlist.Items {
+		if snapshot, exists := snapshots[itemRef(watchlist.Items[i])]; exists {
+			setSnapshot(&watchlist.Items[i], snapshot)
+		}
+	}
+}
+
+// setSnapshot stores a snapshot on an item and renders its media card from it
+func setSnapshot(item *models.WatchlistItem, snapshot *models.MediaSnapshot) {
+	item.Snapshot = snapshot
+	item.Media = snapshot.Media(item.MovieID, itemMediaType(*item))
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+)
+
+// episodeKey identifies an episode within a show
+type episodeKey struct {
+	season  int
+	episode int
+}
+
+// GetItemProgress returns the episode progress of a TV show watchlist item,
+// recomputed against the show's current season layout. The item itself is
+// left as is; only marking episodes changes it.
+func (s *WatchlistService) GetItemProgress(ctx context.Context, userID string, itemID int) (*models.TVProgress, error) {
+	s.mu.RLock()
+	item, err := s.findTVItem(userID, itemID)
+	if err != nil {
+		s.mu.RUnlock()
+		return nil, err
+	}
+	showID := item.MovieID
+	watched := watchedEpisodeSet(item.Progress)
+	s.mu.RUnlock()
+
+	layout, err := s.fetchShowLayout(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeProgress(showID, layout, watched), nil
+}
+
+// MarkEpisodeWatched marks a single episode as watched or unwatched
+func (s *WatchlistService) MarkEpisodeWatched(ctx context.Context, userID string, itemID int, seasonNumber int, episodeNumber int, watched bool) (*models.TVProgress, error) {
+	showID, err := s.itemShowID(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the episode and the show before taking the lock
+	season, err := s.tmdbService.GetTVSeason(ctx, showID, seasonNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+
+	var episode *models.Episode
+	for i := range season.Episodes {
+		if season.Episodes[i].EpisodeNumber == episodeNumber {
+			episode = &season.Episodes[i]
+			break
+		}
+	}
+	if episode == nil {
+		return nil, fmt.Errorf("episode S%02dE%02d not found", seasonNumber, episodeNumber)
+	}
+
+	layout, err := s.fetchShowLayout(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateProgress(userID, itemID, showID, layout, func(set map[episodeKey]models.WatchedEpisode) map[episodeKey]models.WatchedEpisode {
+		if watched {
+			markWatched(set, *episode)
+		} else {
+			delete(set, episodeKey{seasonNumber, episodeNumber})
+		}
+		return set
+	})
+}
+
+// MarkSeasonWatched marks every episode of a season as watched or unwatched
+func (s *WatchlistService) MarkSeasonWatched(ctx context.Context, userID string, itemID int, seasonNumber int, watched bool) (*models.TVProgress, error) {
+	showID, err := s.itemShowID(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the season and the show before taking the lock
+	season, err := s.tmdbService.GetTVSeason(ctx, showID, seasonNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+	layout, err := s.fetchShowLayout(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateProgress(userID, itemID, showID, layout, func(set map[episodeKey]models.WatchedEpisode) map[episodeKey]models.WatchedEpisode {
+		for _, episode := range season.Episodes {
+			if watched {
+				markWatched(set, episode)
+			} else {
+				delete(set, episodeKey{episode.SeasonNumber, episode.EpisodeNumber})
+			}
+		}
+		return set
+	})
+}
+
+// MarkShowWatched marks every regular-season episode of a show as watched or
+// clears all progress
+func (s *WatchlistService) MarkShowWatched(ctx context.Context, userID string, itemID int, watched bool) (*models.TVProgress, error) {
+	showID, err := s.itemShowID(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch every season before taking the lock
+	layout, err := s.fetchShowLayout(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateProgress(userID, itemID, showID, layout, func(set map[episodeKey]models.WatchedEpisode) map[episodeKey]models.WatchedEpisode {
+		if !watched {
+			return make(map[episodeKey]models.WatchedEpisode)
+		}
+		for _, season := range layout.seasons {
+			for _, episode := range season.Episodes {
+				markWatched(set, episode)
+			}
+		}
+		return set
+	})
+}
+
+// itemShowID returns the show a TV watchlist item tracks. The lock is only
+// held for the lookup so that TMDB calls happen outside it.
+func (s *WatchlistService) itemShowID(userID string, itemID int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, err := s.findTVItem(userID, itemID)
+	if err != nil {
+		return 0, err
+	}
+	return item.MovieID, nil
+}
+
+// updateProgress applies a change to an item's watched set and stores the
+// recomputed progress. The show must be fetched beforehand; the lock is only
+// held to find the item and apply the change, which sees the item's latest
+// watched set.
+func (s *WatchlistService) updateProgress(userID string, itemID int, showID int, layout *showLayout, change func(map[episodeKey]models.WatchedEpisode) map[episodeKey]models.WatchedEpisode) (*models.TVProgress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The item may have been removed while TMDB was called
+	item, err := s.findTVItem(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.MovieID != showID {
+		return nil, fmt.Errorf("watchlist item not found")
+	}
+
+	set := change(watchedEpisodeSet(item.Progress))
+	applyProgress(item, computeProgress(showID, layout, set), layout)
+
+	return item.Progress, nil
+}
+
+// findItem looks up a watchlist item by ID; callers must hold the lock
+func (s *WatchlistService) findItem(userID string, itemID int) (*models.WatchlistItem, error) {
+	watchlist, exists := s.watchlists[userID]
+	if !exists {
+		return nil, fmt.Errorf("watchlist not found")
+	}
+
+	for i := range watchlist.Items {
+		if watchlist.Items[i].ID == itemID {
+			return &watchlist.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("watchlist item not found")
+}
+
+// findTVItem looks up a watchlist item that holds a TV show
+func (s *WatchlistService) findTVItem(userID string, itemID int) (*models.WatchlistItem, error) {
+	item, err := s.findItem(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if itemMediaType(*item) != models.MediaTypeTV {
+		return nil, fmt.Errorf("episode progress is only tracked for TV shows")
+	}
+	return item, nil
+}
+
+// showLayout is a show's details and the episodes of its regular seasons,
+// in season order
+type showLayout struct {
+	tv      *models.Media
+	seasons []*models.Season
+}
+
+// fetchShowLayout fetches a show and the episode list of every regular
+// season. Specials (season 0) don't count toward a show's progress.
+func (s *WatchlistService) fetchShowLayout(ctx context.Context, showID int) (*showLayout, error) {
+	tv, err := s.tmdbService.GetTVDetails(ctx, showID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV show: %w", err)
+	}
+
+	var numbers []int
+	for _, summary := range tv.Seasons {
+		if summary.SeasonNumber != 0 {
+			numbers = append(numbers, summary.SeasonNumber)
+		}
+	}
+
+	seasons := make([]*models.Season, len(numbers))
+	errs := make([]error, len(numbers))
+	runPool(ctx, len(numbers), func(i int) {
+		seasons[i], errs[i] = s.tmdbService.GetTVSeason(ctx, showID, numbers[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get season %d: %w", numbers[i], err)
+		}
+	}
+
+	return &showLayout{tv: tv, seasons: seasons}, nil
+}
+
+// computeProgress builds a progress record from a watched set, with
+// per-season completion and the next episode taken from the episodes each
+// season actually lists, so renumbered seasons count correctly
+func computeProgress(showID int, layout *showLayout, set map[episodeKey]models.WatchedEpisode) *models.TVProgress {
+	progress := &models.TVProgress{
+		WatchedEpisodes: make([]models.WatchedEpisode, 0, len(set)),
+		Seasons:         []models.SeasonProgress{},
+		UpdatedAt:       time.Now(),
+	}
+
+	for _, episode := range set {
+		// Fall back to the show's typical runtime when TMDB has none for the episode
+		if episode.Runtime == 0 {
+			episode.Runtime = layout.tv.Runtime
+		}
+		progress.WatchedEpisodes = append(progress.WatchedEpisodes, episode)
+		progress.WatchedMinutes += episode.Runtime
+	}
+	sort.Slice(progress.WatchedEpisodes, func(i, j int) bool {
+		a, b := progress.WatchedEpisodes[i], progress.WatchedEpisodes[j]
+		if a.SeasonNumber != b.SeasonNumber {
+			return a.SeasonNumber < b.SeasonNumber
+		}
+		return a.EpisodeNumber < b.EpisodeNumber
+	})
+
+	// Per-season completion and the first unwatched episode
+	for _, season := range layout.seasons {
+		seasonProgress := models.SeasonProgress{
+			SeasonNumber: season.SeasonNumber,
+			Name:         season.Name,
+			EpisodeCount: len(season.Episodes),
+		}
+		for _, episode := range season.Episodes {
+			if _, ok := set[episodeKey{episode.SeasonNumber, episode.EpisodeNumber}]; ok {
+				seasonProgress.WatchedCount++
+			} else if progress.NextEpisode == nil {
+				episode.ShowID = showID
+				episode.GuestStars = nil
+				episode.Crew = nil
+				progress.NextEpisode = &episode
+			}
+		}
+		if seasonProgress.EpisodeCount > 0 {
+			seasonProgress.CompletionPercent = percent(seasonProgress.WatchedCount, seasonProgress.EpisodeCount)
+		}
+
+		progress.Seasons = append(progress.Seasons, seasonProgress)
+		progress.WatchedCount += seasonProgress.WatchedCount
+		progress.TotalEpisodes += seasonProgress.EpisodeCount
+	}
+	if progress.TotalEpisodes > 0 {
+		progress.CompletionPercent = percent(progress.WatchedCount, progress.TotalEpisodes)
+	}
+
+	return progress
+}
+
+// applyProgress stores a new progress record on the item and moves the
+// item's status along; callers must hold the write lock
+func applyProgress(item *models.WatchlistItem, progress *models.TVProgress, layout *showLayout) {
+	if progress.TotalEpisodes > 0 && progress.WatchedCount == progress.TotalEpisodes && !layout.tv.InProduction {
+		item.Status = "completed"
+	} else if progress.WatchedCount > 0 && (item.Status == "to_watch" || item.Status == "" || item.Status == "completed") {
+		item.Status = "watching"
+	}
+
+	item.Progress = progress
+	item.UpdatedAt = time.Now()
+}
+
+// watchedEpisodeSet indexes the watched episodes of a progress record
+func watchedEpisodeSet(progress *models.TVProgress) map[episodeKey]models.WatchedEpisode {
+	set := make(map[episodeKey]models.WatchedEpisode)
+	if progress == nil {
+		return set
+	}
+	for _, episode := range progress.WatchedEpisodes {
+		set[episodeKey{episode.SeasonNumber, episode.EpisodeNumber}] = episode
+	}
+	return set
+}
+
+// markWatched adds an episode to the watched set, keeping the original watch time
+func markWatched(set map[episodeKey]models.WatchedEpisode, episode models.Episode) {
+	key := episodeKey{episode.SeasonNumber, episode.EpisodeNumber}
+	if _, exists := set[key]; exists {
+		return
+	}
+	set[key] = models.WatchedEpisode{
+		SeasonNumber:  episode.SeasonNumber,
+		EpisodeNumber: episode.EpisodeNumber,
+		Runtime:       episode.Runtime,
+		WatchedAt:     time.Now(),
+	}
+}
+
+// percent returns part/total as a percentage rounded to one decimal
+func percent(part, total int) float64 {
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
@@ -0,0 +1,715 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
+)
+
+// Recommendation tuning
+const (
+	maxProfileTitles    = 50 // watchlist titles fetched in full to learn keywords and people
+	maxSeedTitles       = 8  // liked titles whose TMDB recommendations and similar titles join the pool
+	maxDiscoverGenres   = 3  // favorite genres per media type queried through discover
+	detailedCandidates  = 40 // best candidates fetched in full for the final ranking
+	profileCastSize     = 5  // top-billed cast members that count toward a title's profile
+	recommendationsTTL  = 30 * time.Minute
+	maxBecauseOfSupport = 3
+	maxExplanations     = 8 // strongest explanations kept per result
+
+	// followedPersonAffinity is added to the affinity for people the user
+	// follows, so their work counts as liked before any of it is watchlisted
+	followedPersonAffinity = 0.5
+)
+
+// RecommendationWeights is how much each signal counts toward a
+// recommendation's score. Collaborative is the most the collaborative
+// filtering score is blended in, reached once enough neighbors back it.
+type RecommendationWeights struct {
+	Genre         float64 `json:"genre"`
+	Keyword       float64 `json:"keyword"`
+	People        float64 `json:"people"`
+	Language      float64 `json:"language"`
+	Decade        float64 `json:"decade"`
+	Runtime       float64 `json:"runtime"`
+	Rating        float64 `json:"rating"`
+	MediaType     float64 `json:"media_type"`
+	LikedTitle    float64 `json:"liked_title"` // per liked title TMDB relates the candidate to
+	Popularity    float64 `json:"popularity"`
+	Collaborative float64 `json:"collaborative"`
+}
+
+// DefaultRecommendationWeights returns the weights recommendations are
+// served with
+func DefaultRecommendationWeights() RecommendationWeights {
+	return RecommendationWeights{
+		Genre:         0.3,
+		Keyword:       0.2,
+		People:        0.2,
+		Language:      0.1,
+		Decade:        0.05,
+		Runtime:       0.05,
+		Rating:        0.1,
+		MediaType:     0.05,
+		LikedTitle:    0.05,
+		Popularity:    0.05,
+		Collaborative: collaborativeBlend,
+	}
+}
+
+// tasteProfile is what a user likes and dislikes, learned from their watchlist
+type tasteProfile struct {
+	// features maps "kind:value" traits such as "genre:18" or "director:525"
+	// to an affinity between -1 (disliked) and 1 (in everything they like)
+	features   map[string]float64
+	mediaTypes map[string]float64 // share of the watchlist per media type
+	likedVote  float64            // average TMDB score of liked titles
+	examples   map[string]string  // feature -> most liked title that has it
+	followed   map[int]bool       // TMDB person IDs the user follows
+}
+
+// recommendationCandidate is a title in the recommendation pool
+type recommendationCandidate struct {
+	media         models.Media
+	details       *models.Media
+	becauseOf     []weightedItem // liked watchlist titles TMDB relates it to
+	collaborative collaborativePrediction
+}
+
+// weightedItem is a watchlist item with how much it counts toward the profile
+type weightedItem struct {
+	item     models.WatchlistItem
+	weight   float64
+	feedback string // recommendation feedback the item stands in for, if any
+}
+
+// GetRecommendations recommends titles that aren't on a user's watchlist yet.
+// A taste profile is built from the genres, keywords, cast, directors,
+// original language, decade and runtime of watchlisted titles, weighted by
+// the user's ratings and statuses, and blended with an item-item
+// collaborative filtering score when the user and title have enough data.
+// Candidates come from TMDB recommendations and similar titles for liked
+// items, discover queries for favorite genres, the popular lists and titles
+// other users with similar taste saved. Feedback on earlier recommendations
+// hides titles and steers the profile right away, and people the user
+// follows count as liked. The results are diversified by genre, decade and
+// language, and each carries the explanations that make up its score.
+// Users in a running experiment are served their variant's strategy, which
+// is returned along with the recommendations, and the recommendations count
+// as shown to them.
+func (s *WatchlistService) GetRecommendations(ctx context.Context, userID string, limit int) ([]models.WatchlistRecommendation, *models.ExperimentAssignment, error) {
+	recommendations, assignment, err := s.getRecommendations(ctx, userID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.experimentService.RecordImpressions(userID, assignment, recommendations)
+
+	return recommendations, assignment, nil
+}
+
+// getRecommendations builds a user's recommendations like GetRecommendations
+// without recording them as shown, for features that only use them as input
+func (s *WatchlistService) getRecommendations(ctx context.Context, userID string, limit int) ([]models.WatchlistRecommendation, *models.ExperimentAssignment, error) {
+	s.mu.RLock()
+	watchlist, exists := s.watchlists[userID]
+	if !exists {
+		s.mu.RUnlock()
+		return nil, nil, fmt.Errorf("watchlist not found")
+	}
+	items := append([]models.WatchlistItem{}, watchlist.Items...)
+	version := watchlistVersion(watchlist)
+	s.mu.RUnlock()
+	feedback, feedbackRev := s.recommendationFeedback(userID)
+	followed := s.settingsService.GetSettings(ctx, userID).FollowedPeople
+	assignment, weights := s.experimentService.Assign(userID)
+
+	// Generate cache key; any change to the watchlist, feedback, followed
+	// people or served weights misses
+	cacheKey := utils.GenerateCacheKey("recommendations", userID, version, feedbackRev, followed, weights, limit)
+
+	// Check cache first
+	if cached, exists := s.cache.Get(cacheKey); exists {
+		if recommendations, ok := cached.([]models.WatchlistRecommendation); ok {
+			return recommendations, assignment, nil
+		}
+	}
+
+	if len(items) == 0 && len(feedback) == 0 {
+		return []models.WatchlistRecommendation{}, assignment, nil
+	}
+
+	// Titles the user has or waved off are never recommended
+	exclude := make(map[mediaRef]bool, len(items)+len(feedback))
+	for _, item := range items {
+		exclude[itemRef(item)] = true
+	}
+	for _, entry := range feedback {
+		if entry.Feedback != models.FeedbackMoreLikeThis {
+			exclude[mediaRef{mediaType: entry.MediaType, id: entry.MediaID}] = true
+		}
+	}
+
+	// Strongest opinions first
+	weighted := make([]weightedItem, len(items))
+	for i, item := range items {
+		weighted[i] = weightedItem{item: item, weight: itemWeight(item)}
+	}
+	weighted = append(weighted, feedbackItems(feedback)...)
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return math.Abs(weighted[i].weight) > math.Abs(weighted[j].weight)
+	})
+
+	// Learn the user's taste
+	details := s.fetchProfileDetails(ctx, weighted)
+	profile := buildTasteProfile(weighted, details, followed)
+	predictions := s.collaborativePredictions(weighted)
+
+	// Gather and score candidates
+	candidates, err := s.recommendationCandidates(ctx, weighted, exclude, profile, predictions)
+	if err != nil {
+		return nil, nil, err
+	}
+	recommendations := s.rankCandidates(ctx, candidates, profile, weights, limit)
+
+	// Cache the result
+	s.cache.Set(cacheKey, recommendations, recommendationsTTL)
+
+	return recommendations, assignment, nil
+}
+
+// watchlistVersion changes whenever an item is added, removed or updated;
+// callers must hold the lock
+func watchlistVersion(watchlist *models.Watchlist) string {
+	latest := watchlist.UpdatedAt
+	for _, item := range watchlist.Items {
+		if item.UpdatedAt.After(latest) {
+			latest = item.UpdatedAt
+		}
+	}
+	return fmt.Sprintf("%d-%d", len(watchlist.Items), latest.UnixNano())
+}
+
+// itemWeight returns how much a watchlist item pulls the profile toward its
+// traits. Ratings speak loudest, with 5/10 neutral and lower ratings pushing
+// away; unrated items count by status.
+func itemWeight(item models.WatchlistItem) float64 {
+	if item.Rating > 0 {
+		return (item.Rating - 5) / 5
+	}
+
+	switch item.Status {
+	case "watching":
+		return 0.7
+	case "completed":
+		return 0.6
+	case "dropped":
+		return -0.7
+	default:
+		return 0.3
+	}
+}
+
+// fetchProfileDetails fetches full details, including keywords and credits,
+// for the most strongly weighted watchlist titles. Titles that fail to load
+// fall back to their watchlist card.
+func (s *WatchlistService) fetchProfileDetails(ctx context.Context, weighted []weightedItem) map[mediaRef]*models.Media {
+	count := len(weighted)
+	if count > maxProfileTitles {
+		count = maxProfileTitles
+	}
+
+	details := make(map[mediaRef]*models.Media, count)
+	var mu sync.Mutex
+	runPool(ctx, count, func(i int) {
+		ref := itemRef(weighted[i].item)
+		media, err := s.getMediaDetails(ctx, ref.mediaType, ref.id)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		details[ref] = media
+		mu.Unlock()
+	})
+
+	return details
+}
+
+// buildTasteProfile sums the weights of every trait across the watchlist,
+// normalized by the total weight so affinities stay between -1 and 1
+func buildTasteProfile(weighted []weightedItem, details map[mediaRef]*models.Media, followed []int) tasteProfile {
+	profile := tasteProfile{
+		features:   make(map[string]float64),
+		mediaTypes: make(map[string]float64),
+		examples:   make(map[string]string),
+		followed:   make(map[int]bool, len(followed)),
+	}
+	for _, personID := range followed {
+		profile.followed[personID] = true
+	}
+
+	// Strongest liked title per feature, for explanations
+	exampleWeights := make(map[string]float64)
+
+	totalWeight := 0.0
+	likedVoteSum, likedWeight := 0.0, 0.0
+	for _, w := range weighted {
+		ref := itemRef(w.item)
+		media := w.item.Media
+		if detailed, exists := details[ref]; exists {
+			media = *detailed
+		}
+		media.MediaType = ref.mediaType
+
+		for _, feature := range mediaFeatures(media) {
+			profile.features[feature] += w.weight
+			if w.weight > exampleWeights[feature] && media.Title != "" {
+				exampleWeights[feature] = w.weight
+				profile.examples[feature] = media.Title
+			}
+		}
+		profile.mediaTypes[ref.mediaType]++
+		totalWeight += math.Abs(w.weight)
+
+		if w.weight > 0 && media.VoteAverage > 0 {
+			likedVoteSum += media.VoteAverage * w.weight
+			likedWeight += w.weight
+		}
+	}
+
+	if totalWeight > 0 {
+		for feature := range profile.features {
+			profile.features[feature] /= totalWeight
+		}
+	}
+	for mediaType := range profile.mediaTypes {
+		profile.mediaTypes[mediaType] /= float64(len(weighted))
+	}
+	if likedWeight > 0 {
+		profile.likedVote = likedVoteSum / likedWeight
+	}
+
+	return profile
+}
+
+// mediaFeatures returns the traits of a title that make up a taste profile.
+// Show creators count as directors since they steer a series the same way.
+func mediaFeatures(media models.Media) []string {
+	var features []string
+	for _, genreID := range mediaGenreIDs(media) {
+		features = append(features, fmt.Sprintf("genre:%d", genreID))
+	}
+	for _, keyword := range media.Keywords {
+		features = append(features, fmt.Sprintf("keyword:%d", keyword.ID))
+	}
+	for i, cast := range media.Credits.Cast {
+		if i >= profileCastSize {
+			break
+		}
+		features = append(features, fmt.Sprintf("cast:%d", cast.ID))
+	}
+	for _, crew := range media.Credits.Crew {
+		if crew.Job == "Director" {
+			features = append(features, fmt.Sprintf("director:%d", crew.ID))
+		}
+	}
+	if media.TVDetails != nil {
+		for _, creator := range media.CreatedBy {
+			features = append(features, fmt.Sprintf("director:%d", creator.ID))
+		}
+	}
+	if media.OriginalLanguage != "" {
+		features = append(features, "language:"+media.OriginalLanguage)
+	}
+	if year := utils.ParseYear(media.ReleaseDate); year > 0 {
+		features = append(features, fmt.Sprintf("decade:%d", year/10*10))
+	}
+	if bucket := runtimeBucket(media); bucket != "" {
+		features = append(features, "runtime:"+bucket)
+	}
+
+	return features
+}
+
+// runtimeBucket groups runtimes into short, medium and long per media type
+func runtimeBucket(media models.Media) string {
+	if media.Runtime <= 0 {
+		return ""
+	}
+
+	short, long := 90, 130
+	if media.MediaType == models.MediaTypeTV {
+		short, long = 30, 50
+	}
+	switch {
+	case media.Runtime < short:
+		return media.MediaType + ":short"
+	case media.Runtime > long:
+		return media.MediaType + ":long"
+	default:
+		return media.MediaType + ":medium"
+	}
+}
+
+// recommendationCandidates gathers titles from TMDB recommendations and
+// similar titles of liked items, discover queries for favorite genres, the
+// popular lists and collaborative predictions, leaving out excluded titles
+func (s *WatchlistService) recommendationCandidates(ctx context.Context, weighted []weightedItem, exclude map[mediaRef]bool, profile tasteProfile, predictions map[mediaRef]collaborativePrediction) (map[mediaRef]*recommendationCandidate, error) {
+	type source struct {
+		seed  *weightedItem // liked item the titles are related to
+		fetch func() ([]models.Media, error)
+	}
+	var sources []source
+
+	// Related titles of the best liked items
+	seeds := 0
+	for _, w := range weighted {
+		if seeds == maxSeedTitles {
+			break
+		}
+		if w.weight <= 0 {
+			continue
+		}
+		seeds++
+		seed := &w
+		ref := itemRef(w.item)
+		for _, kind := range []string{RelatedRecommendations, RelatedSimilar} {
+			sources = append(sources, source{seed: seed, fetch: func() ([]models.Media, error) {
+				return s.tmdbService.GetRelatedMedia(ctx, ref.mediaType, ref.id, kind, 1)
+			}})
+		}
+	}
+
+	// Favorite genres of each media type the user watches
+	for _, mediaType := range []string{models.MediaTypeMovie, models.MediaTypeTV} {
+		if profile.mediaTypes[mediaType] == 0 {
+			continue
+		}
+		for _, genreID := range favoriteGenres(profile, maxDiscoverGenres) {
+			filter := models.DiscoverFilter{
+				MediaType: mediaType,
+				GenreIDs:  []int{genreID},
+				SortBy:    "popularity.desc",
+				Page:      1,
+			}
+			sources = append(sources, source{fetch: func() ([]models.Media, error) {
+				result, err := s.tmdbService.DiscoverMedia(ctx, filter)
+				if err != nil {
+					return nil, err
+				}
+				return result.Results, nil
+			}})
+		}
+	}
+
+	// What everyone is watching
+	for _, mediaType := range []string{models.MediaTypeMovie, models.MediaTypeTV} {
+		sources = append(sources, source{fetch: func() ([]models.Media, error) {
+			result, err := s.tmdbService.GetPopularMedia(ctx, mediaType, 1)
+			if err != nil {
+				return nil, err
+			}
+			return result.Results, nil
+		}})
+	}
+
+	// What users with similar taste saved
+	if len(predictions) > 0 {
+		sources = append(sources, source{fetch: func() ([]models.Media, error) {
+			return s.collaborativeCandidates(predictions), nil
+		}})
+	}
+
+	results := make([][]models.Media, len(sources))
+	runPool(ctx, len(sources), func(i int) {
+		if media, err := sources[i].fetch(); err == nil {
+			results[i] = media
+		}
+	})
+
+	candidates := make(map[mediaRef]*recommendationCandidate)
+	fetched := 0
+	for i, media := range results {
+		if media != nil {
+			fetched++
+		}
+		for _, m := range media {
+			if m.Adult {
+				continue
+			}
+			ref := mediaRef{mediaType: m.MediaType, id: m.ID}
+			if ref.mediaType == "" {
+				ref.mediaType = models.MediaTypeMovie
+			}
+			if exclude[ref] {
+				continue
+			}
+
+			candidate, exists := candidates[ref]
+			if !exists {
+				candidate = &recommendationCandidate{media: m, collaborative: predictions[ref]}
+				candidates[ref] = candidate
+			}
+			if seed := sources[i].seed; seed != nil && !slices.ContainsFunc(candidate.becauseOf, func(w weightedItem) bool {
+				return itemRef(w.item) == itemRef(seed.item)
+			}) {
+				candidate.becauseOf = append(candidate.becauseOf, *seed)
+			}
+		}
+	}
+
+	if fetched == 0 && len(sources) > 0 {
+		return nil, fmt.Errorf("failed to get recommendation candidates")
+	}
+
+	return candidates, nil
+}
+
+// favoriteGenres returns up to limit genres the user likes most
+func favoriteGenres(profile tasteProfile, limit int) []int {
+	type genreAffinity struct {
+		id       int
+		affinity float64
+	}
+	var genres []genreAffinity
+	for feature, affinity := range profile.features {
+		var id int
+		if _, err := fmt.Sscanf(feature, "genre:%d", &id); err == nil && affinity > 0 {
+			genres = append(genres, genreAffinity{id: id, affinity: affinity})
+		}
+	}
+	sort.Slice(genres, func(i, j int) bool {
+		if genres[i].affinity != genres[j].affinity {
+			return genres[i].affinity > genres[j].affinity
+		}
+		return genres[i].id < genres[j].id
+	})
+
+	ids := []int{}
+	for i := 0; i < len(genres) && i < limit; i++ {
+		ids = append(ids, genres[i].id)
+	}
+	return ids
+}
+
+// rankCandidates scores every candidate on the traits list results carry,
+// then fetches the best ones in full so keywords and people count toward the
+// final ranking
+func (s *WatchlistService) rankCandidates(ctx context.Context, candidates map[mediaRef]*recommendationCandidate, profile tasteProfile, weights RecommendationWeights, limit int) []models.WatchlistRecommendation {
+	pool := make([]*recommendationCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		pool = append(pool, candidate)
+	}
+	scores := make(map[*recommendationCandidate]float64, len(pool))
+	for _, candidate := range pool {
+		scores[candidate] = scoreCandidate(candidate, profile, weights).Score
+	}
+	sort.Slice(pool, func(i, j int) bool {
+		if scores[pool[i]] != scores[pool[j]] {
+			return scores[pool[i]] > scores[pool[j]]
+		}
+		return pool[i].media.ID < pool[j].media.ID
+	})
+
+	// Fetch the front of the pool in full
+	detailed := detailedCandidates
+	if detailed < limit*2 {
+		detailed = limit * 2
+	}
+	if detailed > len(pool) {
+		detailed = len(pool)
+	}
+	pool = pool[:detailed]
+	runPool(ctx, len(pool), func(i int) {
+		media := pool[i].media
+		if details, err := s.getMediaDetails(ctx, media.MediaType, media.ID); err == nil {
+			pool[i].details = details
+		}
+	})
+
+	recommendations := make([]models.WatchlistRecommendation, len(pool))
+	for i, candidate := range pool {
+		recommendations[i] = scoreCandidate(candidate, profile, weights)
+	}
+
+	// Sort by score (highest first)
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	// Return top recommendations, spread across genres, decades and languages
+	return diversify(recommendations, limit)
+}
+
+// scoreCandidate scores how well a candidate fits a taste profile. Every
+// trait, liked title and signal that counts toward the score is recorded as
+// an explanation, and their contributions add up to the score.
+func scoreCandidate(candidate *recommendationCandidate, profile tasteProfile, weights RecommendationWeights) models.WatchlistRecommendation {
+	media := candidate.media
+	if candidate.details != nil {
+		media = *candidate.details
+	}
+
+	var explanations []models.RecommendationExplanation
+	explain := func(explanationType, feature string, affinity, weight float64) {
+		explanations = append(explanations, models.RecommendationExplanation{
+			Type:     explanationType,
+			Feature:  feature,
+			Affinity: affinity,
+			Weight:   weight,
+		})
+	}
+
+	// Sort the title's traits by kind
+	var genres, keywords, people []string
+	single := make(map[string]string)
+	for _, feature := range mediaFeatures(media) {
+		kind, _, _ := strings.Cut(feature, ":")
+		switch kind {
+		case "genre":
+			genres = append(genres, feature)
+		case "keyword":
+			keywords = append(keywords, feature)
+		case "cast", "director":
+			people = append(people, feature)
+		case "language", "decade", "runtime":
+			single[kind] = feature
+		}
+	}
+
+	// Average genre affinity
+	genreMatch := 0.0
+	for _, feature := range genres {
+		genreMatch += profile.affinity(feature) / float64(len(genres))
+		explain(models.ExplanationGenre, feature, profile.affinity(feature), weights.Genre/float64(len(genres)))
+	}
+
+	// Summed affinity for rarer traits where a single shared director or
+	// theme already says a lot, scaled down when the sum is clamped
+	keywordMatch := explainSummed(keywords, profile, weights.Keyword, models.ExplanationKeyword, explain)
+	peopleMatch := explainSummed(people, profile, weights.People, models.ExplanationPerson, explain)
+
+	singleWeights := map[string]float64{"language": weights.Language, "decade": weights.Decade, "runtime": weights.Runtime}
+	for _, kind := range []string{"language", "decade", "runtime"} {
+		if feature, exists := single[kind]; exists {
+			explain(kind, feature, profile.affinity(feature), singleWeights[kind])
+		}
+	}
+
+	// Closeness to the TMDB scores of titles the user liked
+	ratingMatch := 0.0
+	if profile.likedVote > 0 && media.VoteAverage > 0 {
+		ratingMatch = 1.0 - math.Abs(media.VoteAverage-profile.likedVote)/10.0
+		explain(models.ExplanationRating, "", ratingMatch, weights.Rating)
+	}
+
+	if share := profile.mediaTypes[candidate.media.MediaType]; share > 0 {
+		explain(models.ExplanationMediaType, "", share, weights.MediaType)
+	}
+
+	// Titles TMDB relates to several liked items are safer bets
+	for i := range candidate.becauseOf {
+		if i == maxBecauseOfSupport {
+			break
+		}
+		explain(models.ExplanationLikedTitle, "", 1, weights.LikedTitle)
+	}
+
+	// Add popularity bonus
+	if media.Popularity > 0 {
+		explain(models.ExplanationPopularity, "", math.Min(media.Popularity/50.0, 1), weights.Popularity)
+	}
+
+	// Blend in what similar users think, as far as there's data for it
+	collaborative := candidate.collaborative
+	blend := weights.Collaborative * collaborative.confidence
+	for i := range explanations {
+		explanations[i].Weight *= 1 - blend
+	}
+	if blend > 0 {
+		explain(models.ExplanationCollaborative, "", collaborative.score, blend)
+	}
+
+	// The score is what every explanation contributes
+	score := 0.0
+	liked := 0
+	for i := range explanations {
+		explanation := &explanations[i]
+		explanation.Contribution = explanation.Affinity * explanation.Weight
+		score += explanation.Contribution
+		if explanation.Type == models.ExplanationLikedTitle {
+			describeLikedTitle(explanation, candidate.becauseOf[liked])
+			liked++
+		} else {
+			describeExplanation(explanation, media, profile)
+		}
+	}
+	explanations = strongestExplanations(explanations)
+
+	// Lead with the strongest reason for recommendation
+	reason := "Based on your watchlist preferences"
+	if len(explanations) > 0 && explanations[0].Contribution > 0 {
+		reason = explanations[0].Text
+	}
+
+	becauseOf := make([]string, len(candidate.becauseOf))
+	for i, seed := range candidate.becauseOf {
+		becauseOf[i] = seed.item.Media.Title
+	}
+
+	return models.WatchlistRecommendation{
+		Media:              candidate.media,
+		Score:              score,
+		Reason:             reason,
+		GenreMatch:         genreMatch,
+		RatingMatch:        ratingMatch,
+		KeywordMatch:       keywordMatch,
+		PeopleMatch:        peopleMatch,
+		CollaborativeMatch: collaborative.score,
+		BecauseOf:          becauseOf,
+		Explanations:       explanations,
+	}
+}
+
+// explainSummed explains traits whose affinities are summed and clamped
+// between -1 and 1, and returns the clamped sum
+func explainSummed(features []string, profile tasteProfile, weight float64, explanationType string, explain func(string, string, float64, float64)) float64 {
+	sum := 0.0
+	for _, feature := range features {
+		sum += profile.affinity(feature)
+	}
+	match := clampAffinity(sum)
+
+	scale := 1.0
+	if sum != 0 {
+		scale = match / sum
+	}
+	for _, feature := range features {
+		explain(explanationType, feature, profile.affinity(feature), weight*scale)
+	}
+
+	return match
+}
+
+// affinity returns how much the user likes a trait. People they follow count
+// as liked even before any of their work is on the watchlist.
+func (p tasteProfile) affinity(feature string) float64 {
+	affinity := p.features[feature]
+	if p.followed[featurePersonID(feature)] {
+		affinity = clampAffinity(affinity + followedPersonAffinity)
+	}
+	return affinity
+}
+
+// clampAffinity keeps a summed affinity between -1 and 1
+func clampAffinity(affinity float64) float64 {
+	return math.Max(-1, math.Min(1, affinity))
+}
@@ -3,33 +3,53 @@ package services
 import (
 	"context"
 	"fmt"
-	"math"
-	"sort"
+	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/config"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/utils"
 )
 
 // WatchlistService handles watchlist operations and recommendations
 type WatchlistService struct {
-	tmdbService *TMDBService
-	cache       *utils.Cache
+	tmdbService       *TMDBService
+	settingsService   *SettingsService
+	experimentService *ExperimentService
+	cache             *utils.Cache
 	// In a real application, you would have a database here
+	mu         sync.RWMutex
 	watchlists map[string]*models.Watchlist // userID -> watchlist
+
+	modelMu         sync.RWMutex
+	similarityModel *itemSimilarityModel
+
+	feedbackMu   sync.RWMutex
+	feedback     map[string][]models.RecommendationFeedback // userID -> feedback, newest first
+	feedbackRevs map[string]int                             // userID -> number of changes
+	feedbackPath string
 }
 
 // NewWatchlistService creates a new watchlist service instance
-func NewWatchlistService(tmdbService *TMDBService) *WatchlistService {
+func NewWatchlistService(tmdbService *TMDBService, settingsService *SettingsService, experimentService *ExperimentService) *WatchlistService {
 	return &WatchlistService{
-		tmdbService: tmdbService,
-		cache:       utils.NewCache(),
-		watchlists:  make(map[string]*models.Watchlist),
+		tmdbService:       tmdbService,
+		settingsService:   settingsService,
+		experimentService: experimentService,
+		cache:             utils.NewCache(),
+		watchlists:        make(map[string]*models.Watchlist),
+		feedback:          make(map[string][]models.RecommendationFeedback),
+		feedbackRevs:      make(map[string]int),
+		feedbackPath:      filepath.Join(config.AppConfig.Cache.Dir, "recommendation_feedback.json"),
 	}
 }
 
 // CreateWatchlist creates a new watchlist for a user
 func (s *WatchlistService) CreateWatchlist(ctx context.Context, userID string, request models.WatchlistCreateRequest) (*models.Watchlist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Check if user already has a watchlist
 	if _, exists := s.watchlists[userID]; exists {
 		return nil, fmt.Errorf("user already has a watchlist")
@@ -48,38 +68,109 @@ func (s *WatchlistService) CreateWatchlist(ctx context.Context, userID string, r
 
 	s.watchlists[userID] = watchlist
 
-	return watchlist, nil
+	return copyWatchlist(watchlist), nil
 }
 
-// GetWatchlist retrieves a user's watchlist
+// GetWatchlist retrieves a user's watchlist. Items are rendered from their
+// stored snapshots; only items without one are fetched from TMDB.
 func (s *WatchlistService) GetWatchlist(ctx context.Context, userID string) (*models.Watchlist, error) {
+	s.hydrateMissing(ctx, userID)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	watchlist, exists := s.watchlists[userID]
 	if !exists {
 		return nil, fmt.Errorf("watchlist not found")
 	}
 
-	// Populate movie details for each item
-	for i := range watchlist.Items {
-		movie, err := s.tmdbService.GetMovieDetails(ctx, watchlist.Items[i].MovieID)
-		if err == nil {
-			watchlist.Items[i].Movie = *movie
+	return copyWatchlist(watchlist), nil
+}
+
+// GetWatchlistByID retrieves a watchlist by its ID
+func (s *WatchlistService) GetWatchlistByID(ctx context.Context, id int) (*models.Watchlist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, watchlist := range s.watchlists {
+		if watchlist.ID == id {
+			return copyWatchlist(watchlist), nil
 		}
 	}
 
-	return watchlist, nil
+	return nil, fmt.Errorf("watchlist not found")
+}
+
+// copyWatchlist copies a watchlist so callers can use it without holding the lock
+func copyWatchlist(watchlist *models.Watchlist) *models.Watchlist {
+	result := *watchlist
+	result.Items = append([]models.WatchlistItem{}, watchlist.Items...)
+	return &result
 }
 
-// AddToWatchlist adds a movie to a user's watchlist
+// GetWatchlistMediaIDs returns the IDs in a user's watchlist grouped by
+// media type ("movie" or "tv")
+func (s *WatchlistService) GetWatchlistMediaIDs(userID string) map[string]map[int]bool {
+	ids := make(map[string]map[int]bool)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	watchlist, exists := s.watchlists[userID]
+	if !exists {
+		return ids
+	}
+
+	for _, item := range watchlist.Items {
+		mediaType := itemMediaType(item)
+		if ids[mediaType] == nil {
+			ids[mediaType] = make(map[int]bool)
+		}
+		ids[mediaType][item.MovieID] = true
+	}
+
+	return ids
+}
+
+// GetAllWatchlistItems returns a copy of every user's watchlist items
+func (s *WatchlistService) GetAllWatchlistItems() map[string][]models.WatchlistItem {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make(map[string][]models.WatchlistItem, len(s.watchlists))
+	for userID, watchlist := range s.watchlists {
+		items[userID] = append([]models.WatchlistItem{}, watchlist.Items...)
+	}
+
+	return items
+}
+
+// AddToWatchlist adds a movie or TV show to a user's watchlist
 func (s *WatchlistService) AddToWatchlist(ctx context.Context, userID string, request models.WatchlistItemAddRequest) (*models.WatchlistItem, error) {
+	mediaType := request.MediaType
+	if mediaType == "" {
+		mediaType = models.MediaTypeMovie
+	}
+	if mediaType != models.MediaTypeMovie && mediaType != models.MediaTypeTV {
+		return nil, fmt.Errorf("invalid media type: %s", mediaType)
+	}
+
+	// Get movie or TV show details before taking the lock; a failure leaves
+	// the item to be hydrated later
+	media, mediaErr := s.getMediaDetails(ctx, mediaType, request.MovieID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	watchlist, exists := s.watchlists[userID]
 	if !exists {
 		return nil, fmt.Errorf("watchlist not found")
 	}
 
-	// Check if movie already exists in watchlist
+	// Check if the title already exists in watchlist; movie and TV IDs overlap
 	for _, item := range watchlist.Items {
-		if item.MovieID == request.MovieID {
-			return nil, fmt.Errorf("movie already in watchlist")
+		if item.MovieID == request.MovieID && itemMediaType(item) == mediaType {
+			return nil, fmt.Errorf("%s already in watchlist", mediaType)
 		}
 	}
 
@@ -88,6 +179,7 @@ func (s *WatchlistService) AddToWatchlist(ctx context.Context, userID string, re
 		ID:          len(watchlist.Items) + 1,
 		WatchlistID: watchlist.ID,
 		MovieID:     request.MovieID,
+		MediaType:   mediaType,
 		Status:      request.Status,
 		Rating:      request.Rating,
 		Notes:       request.Notes,
@@ -95,20 +187,41 @@ func (s *WatchlistService) AddToWatchlist(ctx context.Context, userID string, re
 		UpdatedAt:   time.Now(),
 	}
 
-	// Get movie details
-	movie, err := s.tmdbService.GetMovieDetails(ctx, request.MovieID)
-	if err == nil {
-		item.Movie = *movie
+	if mediaErr == nil {
+		setSnapshot(&item, models.NewMediaSnapshot(*media))
 	}
 
 	watchlist.Items = append(watchlist.Items, item)
 	watchlist.UpdatedAt = time.Now()
 
+	// Count the add toward the experiment arm that recommended it
+	s.experimentService.RecordAdd(userID, mediaType, request.MovieID)
+
 	return &item, nil
 }
 
+// getMediaDetails fetches a movie or TV show
+func (s *WatchlistService) getMediaDetails(ctx context.Context, mediaType string, id int) (*models.Media, error) {
+	if mediaType == models.MediaTypeTV {
+		return s.tmdbService.GetTVDetails(ctx, id)
+	}
+
+	return s.tmdbService.GetMovieDetails(ctx, id)
+}
+
+// itemMediaType returns an item's media type, treating untyped items as movies
+func itemMediaType(item models.WatchlistItem) string {
+	if item.MediaType == "" {
+		return models.MediaTypeMovie
+	}
+	return item.MediaType
+}
+
 // UpdateWatchlistItem updates a watchlist item
 func (s *WatchlistService) UpdateWatchlistItem(ctx context.Context, userID string, itemID int, request models.WatchlistItemUpdateRequest) (*models.WatchlistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	watchlist, exists := s.watchlists[userID]
 	if !exists {
 		return nil, fmt.Errorf("watchlist not found")
@@ -123,15 +236,19 @@ func (s *WatchlistService) UpdateWatchlistItem(ctx context.Context, userID strin
 			watchlist.Items[i].UpdatedAt = time.Now()
 			watchlist.UpdatedAt = time.Now()
 
-			return &watchlist.Items[i], nil
+			item := watchlist.Items[i]
+			return &item, nil
 		}
 	}
 
 	return nil, fmt.Errorf("watchlist item not found")
 }
 
-// RemoveFromWatchlist removes a movie from a user's watchlist
+// RemoveFromWatchlist removes a movie or TV show from a user's watchlist
 func (s *WatchlistService) RemoveFromWatchlist(ctx context.Context, userID string, itemID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	watchlist, exists := s.watchlists[userID]
 	if !exists {
 		return fmt.Errorf("watchlist not found")
@@ -151,6 +268,9 @@ func (s *WatchlistService) RemoveFromWatchlist(ctx context.Context, userID strin
 
 // GetWatchlistStats returns statistics for a user's watchlist
 func (s *WatchlistService) GetWatchlistStats(ctx context.Context, userID string) (*models.WatchlistStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	watchlist, exists := s.watchlists[userID]
 	if !exists {
 		return nil, fmt.Errorf("watchlist not found")
@@ -181,14 +301,26 @@ func (s *WatchlistService) GetWatchlistStats(ctx context.Context, userID string)
 			stats.DroppedItems++
 		}
 
+		if itemMediaType(item) == models.MediaTypeTV {
+			stats.TVItems++
+		} else {
+			stats.MovieItems++
+		}
+
 		if item.Rating > 0 {
 			totalRating += item.Rating
 			ratingCount++
 		}
 
-		// Add runtime to total hours
-		if item.Movie.Runtime > 0 {
-			stats.TotalHours += item.Movie.Runtime
+		// Add runtime to total hours. TV shows count watched episodes only, as
+		// their runtime is the length of a single episode.
+		if itemMediaType(item) == models.MediaTypeTV {
+			if item.Progress != nil {
+				stats.TotalHours += item.Progress.WatchedMinutes
+				stats.WatchedEpisodes += item.Progress.WatchedCount
+			}
+		} else if item.Media.Runtime > 0 {
+			stats.TotalHours += item.Media.Runtime
 		}
 	}
 
@@ -202,221 +334,24 @@ func (s *WatchlistService) GetWatchlistStats(ctx context.Context, userID string)
 	return stats, nil
 }
 
-// GetRecommendations generates movie recommendations based on user's watchlist
-func (s *WatchlistService) GetRecommendations(ctx context.Context, userID string, limit int) ([]models.WatchlistRecommendation, error) {
-	// Generate cache key
-	cacheKey := utils.GenerateCacheKey("recommendations", userID, limit)
-
-	// Check cache first
-	if cached, exists := s.cache.Get(cacheKey); exists {
-		if recommendations, ok := cached.([]models.WatchlistRecommendation); ok {
-			return recommendations, nil
-		}
-	}
-
-	watchlist, exists := s.watchlists[userID]
-	if !exists {
-		return nil, fmt.Errorf("watchlist not found")
-	}
-
-	if len(watchlist.Items) == 0 {
-		return []models.WatchlistRecommendation{}, nil
-	}
-
-	// Analyze user preferences
-	preferences := s.analyzeUserPreferences(watchlist)
-
-	// Get trending movies as candidate recommendations
-	trending, err := s.tmdbService.GetTrendingMedia(ctx, "week", 1, "all")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get trending movies: %w", err)
-	}
-
-	// Score and rank recommendations
-	recommendations := s.scoreRecommendations(trending.Results, preferences, limit)
-
-	// Cache the result
-	s.cache.Set(cacheKey, recommendations, 30*time.Minute)
-
-	return recommendations, nil
-}
-
-// analyzeUserPreferences analyzes user's watchlist to determine preferences
-func (s *WatchlistService) analyzeUserPreferences(watchlist *models.Watchlist) map[string]float64 {
-	preferences := make(map[string]float64)
-	genreCounts := make(map[int]int)
-	ratingSum := 0.0
-	ratingCount := 0
-
-	// Count genres and calculate average rating
-	for _, item := range watchlist.Items {
-		for _, genreID := range item.Movie.GenreIDs {
-			genreCounts[genreID]++
-		}
-
-		if item.Rating > 0 {
-			ratingSum += item.Rating
-			ratingCount++
-		}
-	}
-
-	// Calculate genre preferences
-	totalItems := len(watchlist.Items)
-	if totalItems > 0 {
-		for genreID, count := range genreCounts {
-			preferences[fmt.Sprintf("genre_%d", genreID)] = float64(count) / float64(totalItems)
-		}
-	}
-
-	// Calculate average rating preference
-	if ratingCount > 0 {
-		preferences["avg_rating"] = ratingSum / float64(ratingCount)
-	}
-
-	// Calculate year preferences
-	yearCounts := make(map[int]int)
-	for _, item := range watchlist.Items {
-		year := utils.ParseYear(item.Movie.ReleaseDate)
-		if year > 0 {
-			yearCounts[year]++
-		}
-	}
-
-	for year, count := range yearCounts {
-		preferences[fmt.Sprintf("year_%d", year)] = float64(count) / float64(totalItems)
-	}
-
-	return preferences
-}
-
-// scoreRecommendations scores and ranks movie recommendations
-func (s *WatchlistService) scoreRecommendations(candidates []models.Movie, preferences map[string]float64, limit int) []models.WatchlistRecommendation {
-	var recommendations []models.WatchlistRecommendation
-
-	for _, movie := range candidates {
-		score := 0.0
-		genreMatch := 0.0
-		ratingMatch := 0.0
-
-		// Calculate genre match
-		for _, genreID := range movie.GenreIDs {
-			if pref, exists := preferences[fmt.Sprintf("genre_%d", genreID)]; exists {
-				genreMatch += pref
-			}
-		}
-
-		// Normalize genre match
-		if len(movie.GenreIDs) > 0 {
-			genreMatch = genreMatch / float64(len(movie.GenreIDs))
-		}
-
-		// Calculate rating match
-		if avgRating, exists := preferences["avg_rating"]; exists && movie.VoteAverage > 0 {
-			ratingDiff := math.Abs(movie.VoteAverage - avgRating)
-			ratingMatch = 1.0 - (ratingDiff / 10.0) // Higher score for closer ratings
-		}
-
-		// Calculate year match
-		year := utils.ParseYear(movie.ReleaseDate)
-		yearMatch := 0.0
-		if year > 0 {
-			if yearPref, exists := preferences[fmt.Sprintf("year_%d", year)]; exists {
-				yearMatch = yearPref
-			}
-		}
-
-		// Calculate overall score
-		score = (genreMatch * 0.5) + (ratingMatch * 0.3) + (yearMatch * 0.2)
-
-		// Add popularity bonus
-		if movie.Popularity > 0 {
-			popularityBonus := math.Min(movie.Popularity/100.0, 0.1) // Max 10% bonus
-			score += popularityBonus
-		}
-
-		// Determine reason for recommendation
-		reason := "Based on your watchlist preferences"
-		if genreMatch > 0.5 {
-			reason = "Similar genres to your favorites"
-		} else if ratingMatch > 0.7 {
-			reason = "Matches your rating preferences"
-		} else if yearMatch > 0.3 {
-			reason = "From your preferred time period"
-		}
-
-		recommendations = append(recommendations, models.WatchlistRecommendation{
-			Movie:       movie,
-			Score:       score,
-			Reason:      reason,
-			GenreMatch:  genreMatch,
-			RatingMatch: ratingMatch,
-		})
+// mediaGenreIDs returns the genre IDs of a title. List results carry genre_ids
+// while details responses only carry full genres.
+func mediaGenreIDs(movie models.Media) []int {
+	if len(movie.GenreIDs) > 0 {
+		return movie.GenreIDs
 	}
 
-	// Sort by score (highest first)
-	sort.Slice(recommendations, func(i, j int) bool {
-		return recommendations[i].Score > recommendations[j].Score
-	})
-
-	// Return top recommendations
-	if len(recommendations) > limit {
-		recommendations = recommendations[:limit]
+	ids := make([]int, len(movie.Genres))
+	for i, genre := range movie.Genres {
+		ids[i] = genre.ID
 	}
-
-	return recommendations
+	return ids
 }
 
-// GetSimilarMovies finds movies similar to a given movie
-func (s *WatchlistService) GetSimilarMovies(ctx context.Context, movieID int, limit int) ([]models.Movie, error) {
-	// Generate cache key
-	cacheKey := utils.GenerateCacheKey("similar_movies", movieID, limit)
-
-	// Check cache first
-	if cached, exists := s.cache.Get(cacheKey); exists {
-		if movies, ok := cached.([]models.Movie); ok {
-			return movies, nil
-		}
-	}
-
-	// Get the source movie
-	sourceMovie, err := s.tmdbService.GetMovieDetails(ctx, movieID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get source movie: %w", err)
-	}
-
-	// Get trending movies as candidates
-	trending, err := s.tmdbService.GetTrendingMedia(ctx, "week", 1, "all")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get trending movies: %w", err)
-	}
-
-	// Calculate similarities
-	var similarMovies []models.Movie
-	for _, movie := range trending.Results {
-		if movie.ID != movieID {
-			similarity := utils.CalculateSimilarity(*sourceMovie, movie)
-			if similarity > 0.1 { // Only include movies with some similarity
-				similarMovies = append(similarMovies, movie)
-			}
-		}
-	}
-
-	// Sort by similarity
-	sort.Slice(similarMovies, func(i, j int) bool {
-		sim1 := utils.CalculateSimilarity(*sourceMovie, similarMovies[i])
-		sim2 := utils.CalculateSimilarity(*sourceMovie, similarMovies[j])
-		return sim1 > sim2
-	})
-
-	// Limit results
-	if len(similarMovies) > limit {
-		similarMovies = similarMovies[:limit]
-	}
-
-	// Cache the result
-	s.cache.Set(cacheKey, similarMovies, 30*time.Minute)
-
-	return similarMovies, nil
+// CleanupCache removes expired entries from the cache
+func (s *WatchlistService) CleanupCache(ctx context.Context) error {
+	s.cache.Cleanup()
+	return nil
 }
 
 // Close closes the service and cleans up resources
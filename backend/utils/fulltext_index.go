@@ -0,0 +1,338 @@
+package utils
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BM25 tuning parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// FieldWeights controls how much a match in each document field counts
+var FieldWeights = map[string]float64{
+	"title":          3.0,
+	"original_title": 2.0,
+	"tagline":        1.5,
+	"keywords":       1.5,
+	"cast":           1.2,
+	"crew":           1.0,
+	"overview":       1.0,
+}
+
+// TextDocument represents a document in the full-text index
+type TextDocument struct {
+	Key    string            `json:"key"`
+	Fields map[string]string `json:"fields"`
+}
+
+// TextHit represents a ranked full-text search result
+type TextHit struct {
+	Key        string            `json:"key"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// FullTextIndex is an in-memory inverted index with BM25 ranking and phrase support
+type FullTextIndex struct {
+	mu          sync.RWMutex
+	docs        map[string]TextDocument
+	lengths     map[string]float64
+	postings    map[string]map[string][]termPosition // term -> doc key -> positions
+	totalLength float64
+}
+
+// termPosition records where a term occurs inside a document
+type termPosition struct {
+	field string
+	pos   int
+}
+
+var phrasePattern = regexp.MustCompile(`"([^"]+)"`)
+
+// NewFullTextIndex creates a new, empty full-text index
+func NewFullTextIndex() *FullTextIndex {
+	return &FullTextIndex{
+		docs:     make(map[string]TextDocument),
+		lengths:  make(map[string]float64),
+		postings: make(map[string]map[string][]termPosition),
+	}
+}
+
+// Add indexes a document, replacing any previous version with the same key
+func (idx *FullTextIndex) Add(doc TextDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(doc.Key)
+
+	length := 0.0
+	for field, text := range doc.Fields {
+		weight := fieldWeight(field)
+		for pos, token := range Tokenize(text) {
+			docs, exists := idx.postings[token]
+			if !exists {
+				docs = make(map[string][]termPosition)
+				idx.postings[token] = docs
+			}
+			docs[doc.Key] = append(docs[doc.Key], termPosition{field: field, pos: pos})
+			length += weight
+		}
+	}
+
+	idx.docs[doc.Key] = doc
+	idx.lengths[doc.Key] = length
+	idx.totalLength += length
+}
+
+// Get returns an indexed document by key
+func (idx *FullTextIndex) Get(key string) (TextDocument, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	doc, exists := idx.docs[key]
+	return doc, exists
+}
+
+// Documents returns a copy of every indexed document
+func (idx *FullTextIndex) Documents() []TextDocument {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	docs := make([]TextDocument, 0, len(idx.docs))
+	for _, doc := range idx.docs {
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// Len returns the number of indexed documents
+func (idx *FullTextIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Remove drops a document from the index
+func (idx *FullTextIndex) Remove(key string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(key)
+}
+
+// remove drops a document from the postings; callers must hold the write lock
+func (idx *FullTextIndex) remove(key string) {
+	doc, exists := idx.docs[key]
+	if !exists {
+		return
+	}
+
+	for _, text := range doc.Fields {
+		for _, token := range Tokenize(text) {
+			if docs, ok := idx.postings[token]; ok {
+				delete(docs, key)
+				if len(docs) == 0 {
+					delete(idx.postings, token)
+				}
+			}
+		}
+	}
+
+	idx.totalLength -= idx.lengths[key]
+	delete(idx.lengths, key)
+	delete(idx.docs, key)
+}
+
+// Search ranks documents against a query with BM25. Quoted phrases in the
+// query must appear verbatim (ignoring punctuation and case) in a single field.
+func (idx *FullTextIndex) Search(query string) []TextHit {
+	// Split the query into phrases and loose terms
+	var phrases [][]string
+	for _, match := range phrasePattern.FindAllStringSubmatch(query, -1) {
+		if tokens := Tokenize(match[1]); len(tokens) > 0 {
+			phrases = append(phrases, tokens)
+		}
+	}
+	terms := Tokenize(phrasePattern.ReplaceAllString(query, " "))
+	for _, phrase := range phrases {
+		terms = append(terms, phrase...)
+	}
+	if len(terms) == 0 {
+		return []TextHit{}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	docCount := float64(len(idx.docs))
+	if docCount == 0 {
+		return []TextHit{}
+	}
+	avgLength := idx.totalLength / docCount
+
+	// Accumulate BM25 scores per document
+	scores := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		docs := idx.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+		df := float64(len(docs))
+		idf := math.Log(1 + (docCount-df+0.5)/(df+0.5))
+
+		for key, positions := range docs {
+			tf := 0.0
+			for _, p := range positions {
+				tf += fieldWeight(p.field)
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*idx.lengths[key]/avgLength)
+			scores[key] += idf * (tf * (bm25K1 + 1)) / (tf + norm)
+		}
+	}
+
+	// Every phrase must match
+	if len(phrases) > 0 {
+		for key := range scores {
+			for _, phrase := range phrases {
+				if !idx.containsPhrase(key, phrase) {
+					delete(scores, key)
+					break
+				}
+			}
+		}
+	}
+
+	hits := make([]TextHit, 0, len(scores))
+	for key, score := range scores {
+		hits = append(hits, TextHit{
+			Key:        key,
+			Score:      math.Round(score*1000) / 1000,
+			Highlights: idx.highlight(key, seen),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Key < hits[j].Key
+	})
+
+	return hits
+}
+
+// containsPhrase checks whether consecutive phrase tokens occur in one field
+func (idx *FullTextIndex) containsPhrase(key string, phrase []string) bool {
+	for _, start := range idx.postings[phrase[0]][key] {
+		matched := true
+		for offset, term := range phrase[1:] {
+			if !hasPosition(idx.postings[term][key], start.field, start.pos+offset+1) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight builds marked-up snippets for every field containing a query term
+func (idx *FullTextIndex) highlight(key string, terms map[string]bool) map[string]string {
+	highlights := make(map[string]string)
+	for field, text := range idx.docs[key].Fields {
+		if snippet, matched := HighlightText(text, terms, 30); matched {
+			highlights[field] = snippet
+		}
+	}
+	return highlights
+}
+
+func hasPosition(positions []termPosition, field string, pos int) bool {
+	for _, p := range positions {
+		if p.field == field && p.pos == pos {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldWeight(field string) float64 {
+	if weight, exists := FieldWeights[field]; exists {
+		return weight
+	}
+	return 1.0
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// HighlightText wraps words matching terms in <mark> tags and trims the text to
+// a window of maxWords words around the first match. The rest of the text is
+// HTML-escaped so the snippet is safe to render as markup.
+func HighlightText(text string, terms map[string]bool, maxWords int) (string, bool) {
+	words := wordPattern.FindAllStringIndex(text, -1)
+
+	first := -1
+	for i, span := range words {
+		if terms[strings.ToLower(text[span[0]:span[1]])] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	// Choose a window of words around the first match
+	startWord := 0
+	endWord := len(words)
+	if len(words) > maxWords {
+		startWord = first - maxWords/3
+		if startWord < 0 {
+			startWord = 0
+		}
+		endWord = startWord + maxWords
+		if endWord > len(words) {
+			endWord = len(words)
+		}
+	}
+
+	var b strings.Builder
+	if startWord > 0 {
+		b.WriteString("…")
+	}
+
+	cursor := words[startWord][0]
+	for _, span := range words[startWord:endWord] {
+		b.WriteString(html.EscapeString(text[cursor:span[0]]))
+		word := text[span[0]:span[1]]
+		if terms[strings.ToLower(word)] {
+			b.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		cursor = span[1]
+	}
+
+	if endWord < len(words) {
+		b.WriteString("…")
+	} else {
+		b.WriteString(html.EscapeString(text[cursor:]))
+	}
+
+	return b.String(), true
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Doreen-Onyango/Movie_Shows_Discovery/backend/models"
@@ -16,6 +17,7 @@ import (
 
 // Cache represents a simple in-memory cache
 type Cache struct {
+	mu   sync.RWMutex
 	data map[string]CacheItem
 }
 
@@ -34,39 +36,60 @@ func NewCache() *Cache {
 
 // Set adds an item to the cache with expiration
 func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.data[key] = CacheItem{
 		Data:      value,
 		ExpiresAt: time.Now().Add(ttl),
 	}
 }
 
-// Get retrieves an item from the cache
+// Get retrieves an item from the cache. Expired items are left for Cleanup
+// to remove.
 func (c *Cache) Get(key string) (interface{}, bool) {
-	item, exists := c.data[key]
-	if !exists {
-		return nil, false
-	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if time.Now().After(item.ExpiresAt) {
-		delete(c.data, key)
+	item, exists := c.data[key]
+	if !exists || time.Now().After(item.ExpiresAt) {
 		return nil, false
 	}
 
 	return item.Data, true
 }
 
+// ExpiresWithin reports whether an item is missing from the cache or expires
+// within d
+func (c *Cache) ExpiresWithin(key string, d time.Duration) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	item, exists := c.data[key]
+	return !exists || time.Now().Add(d).After(item.ExpiresAt)
+}
+
 // Delete removes an item from the cache
 func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	delete(c.data, key)
 }
 
 // Clear removes all items from the cache
 func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.data = make(map[string]CacheItem)
 }
 
 // Cleanup removes expired items from the cache
 func (c *Cache) Cleanup() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	now := time.Now()
 	for key, item := range c.data {
 		if now.After(item.ExpiresAt) {
@@ -88,7 +111,7 @@ func GenerateCacheKey(prefix string, params ...interface{}) string {
 }
 
 // ValidateMovieData validates movie data and provides fallbacks
-func ValidateMovieData(movie *models.Movie) {
+func ValidateMovieData(movie *models.Media) {
 	// Ensure required fields have fallback values
 	if movie.Title == "" {
 		movie.Title = "Unknown Title"
@@ -242,42 +265,8 @@ func FormatDuration(minutes int) string {
 	return fmt.Sprintf("%dm", mins)
 }
 
-// CalculateSimilarity calculates similarity between two movies based on genres
-func CalculateSimilarity(movie1, movie2 models.Movie) float64 {
-	if len(movie1.GenreIDs) == 0 || len(movie2.GenreIDs) == 0 {
-		return 0
-	}
-
-	// Create sets of genre IDs
-	genres1 := make(map[int]bool)
-	for _, id := range movie1.GenreIDs {
-		genres1[id] = true
-	}
-
-	genres2 := make(map[int]bool)
-	for _, id := range movie2.GenreIDs {
-		genres2[id] = true
-	}
-
-	// Calculate intersection and union
-	intersection := 0
-	for id := range genres1 {
-		if genres2[id] {
-			intersection++
-		}
-	}
-
-	union := len(genres1) + len(genres2) - intersection
-
-	if union == 0 {
-		return 0
-	}
-
-	return float64(intersection) / float64(union)
-}
-
 // PaginateResults handles pagination for search results
-func PaginateResults(results []models.Movie, page, perPage int) ([]models.Movie, models.Meta) {
+func PaginateResults(results []models.Media, page, perPage int) ([]models.Media, models.Meta) {
 	totalResults := len(results)
 	totalPages := int(math.Ceil(float64(totalResults) / float64(perPage)))
 
@@ -294,7 +283,7 @@ func PaginateResults(results []models.Movie, page, perPage int) ([]models.Movie,
 	end := start + perPage
 
 	if start >= totalResults {
-		return []models.Movie{}, models.Meta{
+		return []models.Media{}, models.Meta{
 			Page:         page,
 			PerPage:      perPage,
 			TotalPages:   totalPages,
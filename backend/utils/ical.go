@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+// ICalEvent is an all-day event in an iCalendar feed
+type ICalEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// BuildICalendar renders all-day events as an RFC 5545 calendar
+func BuildICalendar(name string, events []ICalEvent) []byte {
+	var b strings.Builder
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Movie Shows Discovery//Release Calendar//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+	// Ask clients to poll twice a day
+	writeICalLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	writeICalLine(&b, "X-PUBLISHED-TTL:PT12H")
+
+	for _, event := range events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+stamp)
+		writeICalLine(&b, "DTSTART;VALUE=DATE:"+event.Date.Format("20060102"))
+		writeICalLine(&b, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		writeICalLine(&b, "TRANSP:TRANSPARENT")
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeICalText escapes characters with special meaning in iCalendar text
+func escapeICalText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// writeICalLine writes a content line, folding it so no line exceeds 75
+// octets, without splitting a UTF-8 character
+func writeICalLine(b *strings.Builder, line string) {
+	// Continuation lines lose one octet to the leading space
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
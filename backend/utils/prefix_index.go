@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"sync"
+)
+
+// PrefixIndex is a trie of terms supporting typo-tolerant prefix lookups.
+// Each inserted term points at one or more opaque keys owned by the caller.
+type PrefixIndex struct {
+	mu   sync.RWMutex
+	root *trieNode
+}
+
+// trieNode represents a single rune in the trie
+type trieNode struct {
+	children map[rune]*trieNode
+	keys     map[string]struct{}
+}
+
+// NewPrefixIndex creates a new, empty prefix index
+func NewPrefixIndex() *PrefixIndex {
+	return &PrefixIndex{
+		root: newTrieNode(),
+	}
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+// Insert associates a key with a term
+func (p *PrefixIndex) Insert(term string, key string) {
+	if term == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	node := p.root
+	for _, r := range term {
+		child, exists := node.children[r]
+		if !exists {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+
+	if node.keys == nil {
+		node.keys = make(map[string]struct{})
+	}
+	node.keys[key] = struct{}{}
+}
+
+// Remove dissociates a key from a term and prunes branches left empty
+func (p *PrefixIndex) Remove(term string, key string) {
+	if term == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Walk down the term, remembering the path for pruning
+	runes := []rune(term)
+	path := make([]*trieNode, 0, len(runes)+1)
+	node := p.root
+	path = append(path, node)
+	for _, r := range runes {
+		child, exists := node.children[r]
+		if !exists {
+			return
+		}
+		node = child
+		path = append(path, node)
+	}
+
+	delete(node.keys, key)
+
+	// Prune nodes that no longer lead to any key
+	for i := len(runes); i > 0; i-- {
+		current := path[i]
+		if len(current.keys) > 0 || len(current.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
+	}
+}
+
+// SearchPrefix returns every key with a term that starts with a string within
+// maxEdits edits of prefix, mapped to the smallest edit distance found.
+// Insertions, deletions, substitutions and adjacent transpositions count as one edit.
+func (p *PrefixIndex) SearchPrefix(prefix string, maxEdits int) map[string]int {
+	results := make(map[string]int)
+	query := []rune(prefix)
+	if len(query) == 0 {
+		return results
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// First row of the Levenshtein matrix: distance from the empty string
+	row := make([]int, len(query)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	for r, child := range p.root.children {
+		p.searchNode(child, r, 0, query, nil, row, maxEdits+1, maxEdits, results)
+	}
+
+	return results
+}
+
+// searchNode walks the trie computing one Levenshtein row per rune. best is the
+// smallest distance between the query and any prefix of the current path.
+func (p *PrefixIndex) searchNode(node *trieNode, r rune, prevRune rune, query []rune, prevPrevRow []int, prevRow []int, best int, maxEdits int, results map[string]int) {
+	row := make([]int, len(query)+1)
+	row[0] = prevRow[0] + 1
+	rowMin := row[0]
+
+	for i := 1; i <= len(query); i++ {
+		cost := 1
+		if query[i-1] == r {
+			cost = 0
+		}
+		row[i] = minInt(row[i-1]+1, prevRow[i]+1, prevRow[i-1]+cost)
+		if prevPrevRow != nil && i > 1 && query[i-1] == prevRune && query[i-2] == r {
+			row[i] = minInt(row[i], prevPrevRow[i-2]+1)
+		}
+		if row[i] < rowMin {
+			rowMin = row[i]
+		}
+	}
+
+	if row[len(query)] < best {
+		best = row[len(query)]
+	}
+
+	if best <= maxEdits {
+		for key := range node.keys {
+			if existing, exists := results[key]; !exists || best < existing {
+				results[key] = best
+			}
+		}
+	}
+
+	// Stop descending once neither this path nor its extensions can match
+	if best > maxEdits && rowMin > maxEdits {
+		return
+	}
+
+	for childRune, child := range node.children {
+		p.searchNode(child, childRune, r, query, prevRow, row, best, maxEdits, results)
+	}
+}
+
+// MaxEditsFor returns the typo tolerance appropriate for a query term length
+func MaxEditsFor(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRedisNil is returned when Redis replies with a nil value, such as GET on
+// a missing key or SET NX on an existing one
+var ErrRedisNil = errors.New("redis: nil reply")
+
+// redisTimeout bounds commands sent without a context deadline
+const redisTimeout = 5 * time.Second
+
+// RedisError is an error reply from Redis
+type RedisError string
+
+func (e RedisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// RedisClient is a small Redis client that sends commands over a pool of
+// connections. It only speaks the subset of RESP the shared store and job
+// locker need.
+type RedisClient struct {
+	addr     string
+	password string
+	dialer   net.Dialer
+
+	mu   sync.Mutex
+	idle []*redisConn
+}
+
+// redisConn is a connection with its buffered reader
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisClient creates a client for the Redis server at addr
+func NewRedisClient(addr, password string) *RedisClient {
+	return &RedisClient{
+		addr:     addr,
+		password: password,
+		dialer:   net.Dialer{Timeout: redisTimeout},
+	}
+}
+
+// Do sends a command and returns its reply: a string, an int64, a []interface{}
+// for arrays, ErrRedisNil for nil replies or a RedisError
+func (c *RedisClient) Do(ctx context.Context, args ...string) (interface{}, error) {
+	rc, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	rc.conn.SetDeadline(deadline)
+
+	reply, err := rc.do(args)
+	var redisErr RedisError
+	if err != nil && !errors.Is(err, ErrRedisNil) && !errors.As(err, &redisErr) {
+		// The connection may be mid-reply, so it can't be reused
+		rc.conn.Close()
+		return nil, fmt.Errorf("failed to run redis %s: %w", args[0], err)
+	}
+	c.put(rc)
+
+	return reply, err
+}
+
+// Ping checks that the server is reachable
+func (c *RedisClient) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections
+func (c *RedisClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rc := range c.idle {
+		rc.conn.Close()
+	}
+	c.idle = nil
+}
+
+// get takes an idle connection or dials a new one
+func (c *RedisClient) get(ctx context.Context) (*redisConn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		rc := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return rc, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	rc := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if c.password != "" {
+		conn.SetDeadline(time.Now().Add(redisTimeout))
+		if _, err := rc.do([]string{"AUTH", c.password}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate to redis: %w", err)
+		}
+	}
+
+	return rc, nil
+}
+
+// put returns a connection to the idle pool
+func (c *RedisClient) put(rc *redisConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.idle = append(c.idle, rc)
+}
+
+// do writes a command and reads its reply
+func (rc *redisConn) do(args []string) (interface{}, error) {
+	w := bufio.NewWriter(rc.conn)
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return rc.readReply()
+}
+
+// readReply reads one RESP reply
+func (rc *redisConn) readReply() (interface{}, error) {
+	line, err := rc.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	kind, value := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return value, nil
+	case '-':
+		return nil, RedisError(value)
+	case ':':
+		return strconv.ParseInt(value, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk length %q", value)
+		}
+		if size < 0 {
+			return nil, ErrRedisNil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(rc.reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length %q", value)
+		}
+		if count < 0 {
+			return nil, ErrRedisNil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			// Nil and error elements are kept so the rest of the array is read
+			item, err := rc.readReply()
+			var redisErr RedisError
+			switch {
+			case errors.Is(err, ErrRedisNil):
+				item = nil
+			case errors.As(err, &redisErr):
+				item = redisErr
+			case err != nil:
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a server that answers each command with the raw RESP reply
+// its handler returns
+type fakeRedis struct {
+	listener net.Listener
+	handler  func(args []string) string
+
+	mu    sync.Mutex
+	dials int
+}
+
+func newFakeRedis(t *testing.T, handler func(args []string) string) *fakeRedis {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeRedis{listener: listener, handler: handler}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.dials++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.handler(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) dialCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.dials
+}
+
+// readCommand reads a command sent as an array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+
+	return args, nil
+}
+
+func TestRedisClientReplies(t *testing.T) {
+	replies := map[string]string{
+		"status":     "+OK\r\n",
+		"integer":    ":42\r\n",
+		"bulk":       "$12\r\nhello\r\nworld\r\n",
+		"empty":      "$0\r\n\r\n",
+		"nil":        "$-1\r\n",
+		"array":      "*3\r\n$1\r\na\r\n:7\r\n*1\r\n+nested\r\n",
+		"nil-array":  "*-1\r\n",
+		"mixed":      "*3\r\n$-1\r\n-ERR bad element\r\n$1\r\nz\r\n",
+		"error":      "-ERR unknown command\r\n",
+		"after":      "+still in sync\r\n",
+		"bad-length": "$x\r\n",
+	}
+	server := newFakeRedis(t, func(args []string) string {
+		return replies[args[1]]
+	})
+	client := NewRedisClient(server.listener.Addr().String(), "")
+	defer client.Close()
+
+	tests := []struct {
+		name string
+		want interface{}
+		err  error
+	}{
+		{name: "status", want: "OK"},
+		{name: "integer", want: int64(42)},
+		{name: "bulk", want: "hello\r\nworld"},
+		{name: "empty", want: ""},
+		{name: "nil", err: ErrRedisNil},
+		{name: "array", want: []interface{}{"a", int64(7), []interface{}{"nested"}}},
+		{name: "nil-array", err: ErrRedisNil},
+		{name: "mixed", want: []interface{}{nil, RedisError("ERR bad element"), "z"}},
+		{name: "error", err: RedisError("ERR unknown command")},
+		{name: "after", want: "still in sync"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Do(context.Background(), "ECHO", tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Do() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.Do(context.Background(), "ECHO", "bad-length"); err == nil {
+		t.Error("Do() accepted a malformed bulk length")
+	}
+}
+
+func TestRedisClientReusesConnections(t *testing.T) {
+	server := newFakeRedis(t, func(args []string) string {
+		if args[0] == "FAIL" {
+			return "-ERR failed\r\n"
+		}
+		return "+PONG\r\n"
+	})
+	client := NewRedisClient(server.listener.Addr().String(), "")
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping() error = %v", err)
+		}
+		// An error reply leaves the connection usable
+		var redisErr RedisError
+		if _, err := client.Do(context.Background(), "FAIL"); !errors.As(err, &redisErr) {
+			t.Fatalf("Do() error = %v, want a RedisError", err)
+		}
+	}
+
+	if dials := server.dialCount(); dials != 1 {
+		t.Errorf("client dialed %d connections, want 1", dials)
+	}
+}
+
+func TestRedisClientDropsBrokenConnections(t *testing.T) {
+	server := newFakeRedis(t, func(args []string) string {
+		if args[0] == "BROKEN" {
+			// Promise more bytes than are sent so the read times out
+			return "$10\r\nabc"
+		}
+		return "+PONG\r\n"
+	})
+	client := NewRedisClient(server.listener.Addr().String(), "")
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Do(ctx, "BROKEN"); err == nil {
+		t.Fatal("Do() succeeded on a truncated reply")
+	}
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if dials := server.dialCount(); dials != 2 {
+		t.Errorf("client dialed %d connections, want 2", dials)
+	}
+}
+
+func TestRedisClientAuthenticates(t *testing.T) {
+	var mu sync.Mutex
+	var commands []string
+	server := newFakeRedis(t, func(args []string) string {
+		mu.Lock()
+		commands = append(commands, strings.Join(args, " "))
+		mu.Unlock()
+		if args[0] == "AUTH" && args[1] != "secret" {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		return "+OK\r\n"
+	})
+
+	client := NewRedisClient(server.listener.Addr().String(), "secret")
+	defer client.Close()
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	mu.Lock()
+	got := fmt.Sprint(commands)
+	mu.Unlock()
+	if got != "[AUTH secret PING]" {
+		t.Errorf("server got %s, want [AUTH secret PING]", got)
+	}
+
+	wrong := NewRedisClient(server.listener.Addr().String(), "guess")
+	defer wrong.Close()
+	var redisErr RedisError
+	if err := wrong.Ping(context.Background()); !errors.As(err, &redisErr) {
+		t.Errorf("Ping() error = %v, want a RedisError", err)
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeText lowercases a string and strips everything but letters, digits and spaces
+func NormalizeText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	lastSpace := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastSpace = false
+			continue
+		}
+		if !lastSpace {
+			b.WriteRune(' ')
+			lastSpace = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// Tokenize splits a string into normalized lowercase word tokens
+func Tokenize(s string) []string {
+	return strings.Fields(NormalizeText(s))
+}